@@ -4,56 +4,36 @@ import (
 	"bytes"
 	"context"
 	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha256"
-	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // authenticate performs OAuth2 authentication to get access token
 func (c *Client) authenticate(ctx context.Context) error {
+	if c.baseURLErr != nil {
+		return c.baseURLErr
+	}
+
 	// Create signature for token request
 	timestamp := c.generateTimestamp()
 	payload := c.clientID + "|" + timestamp
 
-	// Parse private key
-	block, _ := pem.Decode([]byte(c.privateKey))
-	if block == nil {
-		return fmt.Errorf("failed to decode PEM block containing private key")
-	}
-
-	var privateKey *rsa.PrivateKey
-	var err error
-	if parsedKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
-		privateKey = parsedKey
-	} else if parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
-		if rsaKey, ok := parsedKey.(*rsa.PrivateKey); ok {
-			privateKey = rsaKey
-		} else {
-			return fmt.Errorf("private key is not RSA")
-		}
-	} else {
-		return fmt.Errorf("failed to parse private key: %w", err)
+	// Sign payload with the private key
+	privateKey, err := c.signingKey()
+	if err != nil {
+		return err
 	}
-
-	// Create signature
-	hashed := sha256.Sum256([]byte(payload))
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	signatureB64, err := signRSA(privateKey, payload, c.tokenHash(), c.signatureAlgorithm)
 	if err != nil {
-		return fmt.Errorf("failed to sign payload: %w", err)
+		return err
 	}
 
-	// Encode signature to base64
-	signatureB64 := base64.StdEncoding.EncodeToString(signature)
-
 	// Create token request
 	tokenReq := TokenRequest{
 		GrantType: "client_credentials",
@@ -80,14 +60,14 @@ func (c *Client) authenticate(ctx context.Context) error {
 	// Make request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make token request: %w", err)
+		return fmt.Errorf("failed to make token request: %w: %w", ErrNetwork, err)
 	}
 	defer resp.Body.Close()
 
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read token response: %w", err)
+		return fmt.Errorf("failed to read token response: %w: %w", ErrNetwork, err)
 	}
 
 	// Parse response
@@ -101,19 +81,92 @@ func (c *Client) authenticate(ctx context.Context) error {
 	}
 	var authResp AuthResponse
 	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return fmt.Errorf("failed to unmarshal token response: %w", err)
+		return fmt.Errorf("failed to unmarshal token response: %w: %w", ErrDecode, err)
+	}
+
+	// BRI can answer HTTP 200 with an error code and no token in some failure modes
+	if authResp.AccessToken == "" || (authResp.ResponseCode != "" && !NewStructuredBRIAPIResponse(authResp.ResponseCode, "").IsSuccess()) {
+		message := authResp.ResponseMessage
+		if message == "" {
+			message = "token response has no access token"
+		}
+		return &APIError{
+			ResponseCode:    authResp.ResponseCode,
+			ResponseMessage: message,
+		}
+	}
+
+	// Parse expires in from string to integer; a missing value falls back to a short default
+	expiresIn := defaultTokenExpiresIn
+	if value := strings.TrimSpace(authResp.ExpiresIn); value != "" {
+		expiresInUnits, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("failed to parse expires in value '%s': %w", authResp.ExpiresIn, err)
+		}
+		expiresIn = time.Duration(expiresInUnits) * c.tokenExpiryUnit.duration()
 	}
 
 	// Store token
+	c.tokenMu.Lock()
 	c.accessToken = authResp.AccessToken
+	c.tokenType = authResp.TokenType
+	c.tokenIssued = c.now()
+	c.tokenExpiry = c.tokenIssued.Add(expiresIn)
+	expiry := c.tokenExpiry
+	c.tokenMu.Unlock()
 
-	// Parse expires in from string to integer
-	expiresInSeconds, err := strconv.Atoi(authResp.ExpiresIn)
-	if err != nil {
-		return fmt.Errorf("failed to parse expires in value '%s': %w", authResp.ExpiresIn, err)
+	c.logDebug(ctx, "Access token obtained", "tokenType", authResp.TokenType, "expiresIn", expiresIn.String())
+
+	// Invoked outside tokenMu so the callback may use the client
+	if c.onTokenRefresh != nil {
+		c.onTokenRefresh(authResp.AccessToken, expiry)
 	}
+	return nil
+}
 
-	c.tokenExpiry = time.Now().Add(time.Duration(expiresInSeconds) * time.Second)
+// SetToken seeds a previously obtained access token, e.g. one persisted via
+// Config.OnTokenRefresh, so no token request is made while it is valid
+func (c *Client) SetToken(token string, expiry time.Time) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 
-	return nil
+	c.accessToken = token
+	c.tokenType = ""
+	c.tokenIssued = c.now()
+	c.tokenExpiry = expiry
+}
+
+// tokenHash returns the configured token signature hash, defaulting to SHA-256
+func (c *Client) tokenHash() crypto.Hash {
+	if c.tokenSignatureHash == 0 {
+		return crypto.SHA256
+	}
+	return c.tokenSignatureHash
+}
+
+// signingKey returns the RSA private key, parsing it on first use and caching the result
+func (c *Client) signingKey() (*rsa.PrivateKey, error) {
+	c.keyOnce.Do(func() {
+		c.rsaKey, c.rsaKeyErr = parseEncryptedRSAPrivateKey(c.privateKey, c.privateKeyPassphrase)
+		if c.rsaKeyErr != nil {
+			c.rsaKeyErr = fmt.Errorf("%w: %w", ErrInvalidPrivateKey, c.rsaKeyErr)
+		}
+	})
+	return c.rsaKey, c.rsaKeyErr
+}
+
+// TokenExpiryUnit is the unit of the expiresIn value in token responses
+type TokenExpiryUnit int
+
+const (
+	TokenExpirySeconds TokenExpiryUnit = iota // expiresIn is in seconds, per the SNAP specification (default)
+	TokenExpiryMinutes                        // expiresIn is in minutes, as documented by some partner gateways
+)
+
+// duration returns the length of one unit
+func (u TokenExpiryUnit) duration() time.Duration {
+	if u == TokenExpiryMinutes {
+		return time.Minute
+	}
+	return time.Second
 }
@@ -0,0 +1,37 @@
+package gobriva
+
+import (
+	"context"
+	"fmt"
+)
+
+// BalanceInquiry gets the available balance of an account
+func (c *Client) BalanceInquiry(ctx context.Context, req *BalanceInquiryRequest) (*BalanceInquiryResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Ensure authentication
+	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	// Make request
+	resp, err := c.makeRequest(ctx, "POST", "/snap/v1.0/balance-inquiry", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make balance inquiry request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response
+	balanceResp, apiErr, err := decodeResponse[BalanceInquiryResponse](resp, "balance inquiry", c.now())
+	if err != nil {
+		return nil, err
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return balanceResp, nil
+}
@@ -0,0 +1,219 @@
+package gobriva
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BatchOptions configures batch operations
+type BatchOptions struct {
+	Concurrency      int  // Maximum requests in flight (default 1)
+	StopOnFirstError bool // Cancel in-flight work and stop dispatching after the first error
+}
+
+// CreateResult holds the outcome of a single virtual account creation within a batch
+type CreateResult struct {
+	Index    int                           // Position of the request in the input slice
+	Response *CreateVirtualAccountResponse // Response on success
+	Err      error                         // Error on failure
+}
+
+// InquiryResult holds the outcome of a single virtual account inquiry within a batch
+type InquiryResult struct {
+	Index    int                            // Position of the request in the input slice
+	Response *InquiryVirtualAccountResponse // Response on success
+	Err      error                          // Error on failure
+}
+
+// CreateVirtualAccounts creates virtual accounts in parallel.
+// Results are ordered by input index; with StopOnFirstError only the results collected
+// before cancellation are returned.
+func (c *Client) CreateVirtualAccounts(ctx context.Context, reqs []*CreateVirtualAccountRequest, opts BatchOptions) []CreateResult {
+	outcomes := runBatch(ctx, reqs, opts, c.CreateVirtualAccount)
+
+	results := make([]CreateResult, len(outcomes))
+	for i, o := range outcomes {
+		results[i] = CreateResult{Index: o.index, Response: o.resp, Err: o.err}
+	}
+	return results
+}
+
+// BatchResult is the outcome of a single creation made by CreateVirtualAccountsBatch
+type BatchResult = CreateResult
+
+// CreateVirtualAccountsBatch creates virtual accounts with at most concurrency requests in flight.
+// It is CreateVirtualAccounts without StopOnFirstError: results are ordered by input index and
+// requests not yet dispatched when ctx is cancelled are left out.
+func (c *Client) CreateVirtualAccountsBatch(ctx context.Context, reqs []*CreateVirtualAccountRequest, concurrency int) []BatchResult {
+	return c.CreateVirtualAccounts(ctx, reqs, BatchOptions{Concurrency: concurrency})
+}
+
+// InquiryVirtualAccounts inquires virtual accounts in parallel.
+// Results are ordered by input index; with StopOnFirstError only the results collected
+// before cancellation are returned.
+func (c *Client) InquiryVirtualAccounts(ctx context.Context, reqs []*InquiryVirtualAccountRequest, opts BatchOptions) []InquiryResult {
+	outcomes := runBatch(ctx, reqs, opts, c.InquiryVirtualAccount)
+
+	results := make([]InquiryResult, len(outcomes))
+	for i, o := range outcomes {
+		results[i] = InquiryResult{Index: o.index, Response: o.resp, Err: o.err}
+	}
+	return results
+}
+
+// DeleteResult holds the outcome of a single virtual account deletion within a batch
+type DeleteResult struct {
+	Index    int                           // Position of the request among the distinct VAs
+	Request  *DeleteVirtualAccountRequest  // Delete request sent for the VA
+	Response *DeleteVirtualAccountResponse // Response on success; nil if the VA was already gone
+	Err      error                         // Error on failure; nil when BRI reports the VA not found
+}
+
+// DeleteFromReport deletes each distinct virtual account in a report with at most
+// concurrency requests in flight. A VA that no longer exists counts as deleted.
+// A nil report has nothing to delete.
+func (c *Client) DeleteFromReport(ctx context.Context, report *VirtualAccountReportResponse, concurrency int) []DeleteResult {
+	if report == nil {
+		return nil
+	}
+
+	var reqs []*DeleteVirtualAccountRequest
+	seen := map[string]bool{}
+	for _, trx := range report.VirtualAccountData {
+		key := trx.PartnerServiceID + "|" + trx.CustomerNo + "|" + trx.VirtualAccountNo
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		reqs = append(reqs, &DeleteVirtualAccountRequest{
+			PartnerServiceID: trx.PartnerServiceID,
+			CustomerNo:       trx.CustomerNo,
+			VirtualAccountNo: trx.VirtualAccountNo,
+			TrxID:            trx.TrxID,
+		})
+	}
+
+	outcomes := runBatch(ctx, reqs, BatchOptions{Concurrency: concurrency}, c.DeleteVirtualAccount)
+
+	results := make([]DeleteResult, len(outcomes))
+	for i, o := range outcomes {
+		err := o.err
+		var briErr *StructuredBRIAPIResponse
+		if errors.As(err, &briErr) && briErr.HTTPStatusCode == http.StatusNotFound {
+			err = nil
+		}
+		results[i] = DeleteResult{Index: o.index, Request: reqs[o.index], Response: o.resp, Err: err}
+	}
+	return results
+}
+
+// batchOutcome is the result of one call made by runBatch
+type batchOutcome[Resp any] struct {
+	index int
+	resp  Resp
+	err   error
+}
+
+// runBatch calls fn for each request with bounded concurrency and returns the
+// outcomes of all dispatched calls in input order
+func runBatch[Req, Resp any](ctx context.Context, reqs []Req, opts BatchOptions, fn func(context.Context, Req) (Resp, error)) []batchOutcome[Resp] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make([]*batchOutcome[Resp], len(reqs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, req := range reqs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// Stop dispatching once cancelled, even if a slot was free
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, req Req) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := fn(ctx, req)
+			outcomes[i] = &batchOutcome[Resp]{index: i, resp: resp, err: err}
+			if err != nil && opts.StopOnFirstError {
+				cancel()
+			}
+		}(i, req)
+	}
+	wg.Wait()
+
+	collected := make([]batchOutcome[Resp], 0, len(reqs))
+	for _, o := range outcomes {
+		if o != nil {
+			collected = append(collected, *o)
+		}
+	}
+	return collected
+}
+
+// BatchSummary summarizes the outcome of a batch operation
+type BatchSummary struct {
+	Total         int
+	Succeeded     int
+	Failed        int
+	ByCategory    map[HttpCategory]int // Result counts per BRI response category
+	NonAPIErrors  int                  // Failures without a BRI response, e.g. network or validation errors
+	FailedIndices []int                // Indices of failed results, in ascending order
+}
+
+// SummarizeBatch counts batch results by outcome and category
+func SummarizeBatch(results []CreateResult) BatchSummary {
+	summary := BatchSummary{
+		Total:      len(results),
+		ByCategory: map[HttpCategory]int{},
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			summary.Succeeded++
+			summary.ByCategory[CategorySuccess]++
+			continue
+		}
+
+		summary.Failed++
+		summary.FailedIndices = append(summary.FailedIndices, result.Index)
+
+		var briErr *StructuredBRIAPIResponse
+		if errors.As(result.Err, &briErr) {
+			summary.ByCategory[briErr.responseCategory()]++
+		} else {
+			summary.NonAPIErrors++
+		}
+	}
+
+	sort.Ints(summary.FailedIndices)
+	return summary
+}
+
+// String returns a one-line summary of the batch
+func (s BatchSummary) String() string {
+	categories := make([]string, 0, len(s.ByCategory))
+	for category, count := range s.ByCategory {
+		categories = append(categories, fmt.Sprintf("%s=%d", category, count))
+	}
+	sort.Strings(categories)
+
+	return fmt.Sprintf("total=%d succeeded=%d failed=%d [%s] nonAPIErrors=%d failedIndices=%v",
+		s.Total, s.Succeeded, s.Failed, strings.Join(categories, " "), s.NonAPIErrors, s.FailedIndices)
+}
@@ -3,18 +3,24 @@ package gobriva
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
-	"crypto/sha512"
 	"crypto/tls"
-	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
-	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,10 +32,105 @@ const (
 	// Default timeout
 	defaultTimeout = 30 * time.Second
 
+	// Token lifetime assumed when the token response omits expiresIn
+	defaultTokenExpiresIn = 60 * time.Second
+
+	// Layout of the X-TIMESTAMP header (ISO 8601 with milliseconds)
+	timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
 	// Maximum number of bytes of body to include in logs (avoid huge logs)
 	maxLogBodySize = 8 * 1024 // 8 KiB
+
+	// Response code returned by BRI when the access token has expired
+	tokenExpiredResponseCode = "4012704"
+
+	// Response code returned by BRI while the system is under maintenance
+	maintenanceResponseCode = "5002704"
+
+	// Wait applied when a maintenance response carries no Retry-After header
+	defaultMaintenanceWait = 30 * time.Second
+
+	// Response code returned by BRI when the trxId already exists
+	trxIDConflictResponseCode = "4092703"
+
+	// Maximum retries of an SDK-generated trxId after a conflict
+	maxTrxIDRegenerations = 3
 )
 
+// ErrMissingVAData is returned when RequireVAData is set and a successful response has no virtualAccountData
+var ErrMissingVAData = errors.New("successful response is missing virtualAccountData")
+
+// Environment identifies a BRI API environment
+type Environment string
+
+const (
+	EnvironmentProduction Environment = "production"
+	EnvironmentSandbox    Environment = "sandbox"
+)
+
+// ErrNetwork wraps failures to reach BRI or read its response, such as connection errors and timeouts
+var ErrNetwork = errors.New("network error")
+
+// ErrDecode wraps failures to decode a response from BRI
+var ErrDecode = errors.New("decode error")
+
+// DecodeError is returned when a BRI response body cannot be decoded.
+// It matches ErrDecode with errors.Is and keeps the raw body for debugging.
+type DecodeError struct {
+	Operation  string // Operation whose response failed to decode
+	StatusCode int    // HTTP status of the response
+	RawBody    string // Response body, truncated to 8 KiB
+	Offset     int64  // Byte offset of a JSON syntax error in the body, 0 for other errors
+	Snippet    string // Body around Offset, empty for other errors
+	Err        error  // Underlying unmarshal error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Offset > 0 {
+		return fmt.Sprintf("failed to unmarshal %s response: %v: %v at offset %d near %q", e.Operation, ErrDecode, e.Err, e.Offset, e.Snippet)
+	}
+	return fmt.Sprintf("failed to unmarshal %s response: %v: %v", e.Operation, ErrDecode, e.Err)
+}
+
+// Unwrap returns ErrDecode and the underlying unmarshal error
+func (e *DecodeError) Unwrap() []error {
+	return []error{ErrDecode, e.Err}
+}
+
+// decodeSnippetRadius is the number of body bytes kept on each side of a syntax error
+const decodeSnippetRadius = 20
+
+// newDecodeError builds a DecodeError, truncating the body to maxLogBodySize and
+// locating JSON syntax errors
+func newDecodeError(operation string, statusCode int, body []byte, err error) *DecodeError {
+	decodeErr := &DecodeError{Operation: operation, StatusCode: statusCode, Err: err}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		decodeErr.Offset = syntaxErr.Offset
+		start := max(syntaxErr.Offset-decodeSnippetRadius, 0)
+		end := min(syntaxErr.Offset+decodeSnippetRadius, int64(len(body)))
+		if start < end {
+			decodeErr.Snippet = string(body[start:end])
+		}
+	}
+
+	if len(body) > maxLogBodySize {
+		body = body[:maxLogBodySize]
+	}
+	decodeErr.RawBody = string(body)
+	return decodeErr
+}
+
+// ErrInvalidBaseURL is returned when Config.BaseURL is not an absolute URL
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// ErrInvalidPrivateKey is returned when Config.PrivateKey cannot be parsed or decrypted
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// ErrVANotFoundAfterCreate is returned when VerifyAfterCreate is set and the created VA cannot be inquired
+var ErrVANotFoundAfterCreate = errors.New("virtual account not found after create")
+
 // HTTPClient interface for making HTTP requests
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -55,6 +156,119 @@ type Config struct {
 	Logger        *slog.Logger  // Optional: custom slog.Logger; if provided the client will use it (no global changes)
 	HTTPClient    HTTPClient    // Optional: custom HTTP client for testing
 	Authenticator Authenticator // Optional: custom authenticator for testing
+
+	// TokenExpiryUnit is the unit of expiresIn in token responses (default: TokenExpirySeconds)
+	TokenExpiryUnit TokenExpiryUnit
+
+	// TokenRefreshSkew refreshes the access token this long before its reported expiry.
+	// Tune it using Stats().TokenExpiredRetries if tokens expire mid-flight.
+	TokenRefreshSkew time.Duration
+
+	// OnTokenRefresh, if set, is called with each newly obtained access token and its expiry,
+	// e.g. to persist it for Client.SetToken after a restart
+	OnTokenRefresh func(token string, expiry time.Time)
+
+	// RequireVAData treats a successful response without virtualAccountData as an error.
+	// When false, VirtualAccountData may be nil on success and must be checked before use.
+	RequireVAData bool
+
+	// RandReader is the randomness source for X-EXTERNAL-ID generation (default: crypto/rand.Reader).
+	// Inject a deterministic reader in tests for reproducible IDs.
+	RandReader io.Reader
+
+	// MaxConcurrentRequests limits in-flight API requests (0 means unlimited).
+	// Requests are signed only after acquiring a slot, so queued requests never send stale timestamps.
+	MaxConcurrentRequests int
+
+	// OnRequestSigned is called with the exact body bytes that were hashed for the signature
+	// and sent on the wire. Useful for debugging signature mismatches.
+	OnRequestSigned func(SignedRequest)
+
+	// BaseURL, if set, replaces the production or sandbox URL derived from IsSandbox, e.g. to route
+	// traffic through an API gateway. It must be an absolute URL; an invalid one is logged as a
+	// warning by NewClient and returned by CheckEnvironment and every API call.
+	BaseURL string
+
+	// ExpectedEnvironment, if set, is cross-checked against the URL derived from IsSandbox, or
+	// against IsSandbox itself when BaseURL is set. A mismatch is logged as a warning by
+	// NewClient and reported by CheckEnvironment.
+	ExpectedEnvironment Environment
+
+	// VerifyAfterCreate inquires each newly created VA to confirm it exists before returning
+	VerifyAfterCreate bool
+
+	// RetryBudget caps the retries shared across the client; zero leaves retries unlimited.
+	// When the budget is exhausted the original error is returned without retrying.
+	RetryBudget int
+
+	// RetryBudgetRefillRate is the number of retries per second returned to the budget
+	RetryBudgetRefillRate float64
+
+	// RegenerateTrxIDOnConflict lets CreateVirtualAccount generate the trxId when it is empty,
+	// and regenerate and retry when BRI reports the trxId already exists (4092703).
+	// User-supplied trxIds are never replaced.
+	RegenerateTrxIDOnConflict bool
+
+	// MaxReportSpan is the widest report window GetVirtualAccountReport accepts (default: 31 days).
+	// Wider windows are rejected locally with 4002716.
+	MaxReportSpan time.Duration
+
+	// Clock returns the current time used for X-TIMESTAMP, token expiry, retry budget refills,
+	// error timestamps and rate limit resets (default: time.Now). Inject a fixed clock for
+	// reproducible signatures. Retry and polling waits also end once Clock has advanced past them.
+	Clock func() time.Time
+
+	// Signer, if set, replaces the built-in HMAC request signature entirely, e.g. to delegate
+	// signing to an HSM. It receives the same inputs the built-in signer hashes.
+	Signer func(method, path, accessToken, body, timestamp string) (signature string, err error)
+
+	// CanonicalJSON serializes request bodies with object keys sorted at every level, so the
+	// signed and transmitted bytes do not depend on struct field order
+	CanonicalJSON bool
+
+	// RetryPolicy retries transient failures such as 502/503/504 responses (default: no retries)
+	RetryPolicy RetryPolicy
+
+	// TreatAsSuccess lists response codes, such as 4092701 (VA already exists) for idempotent
+	// creates, that VA operations decode as a normal response instead of returning an error
+	TreatAsSuccess []string
+
+	// MetricsObserver, if set, is notified after every API request, including failed ones
+	MetricsObserver MetricsObserver
+
+	// Tracer, if set, traces every API request as a span named after the request path
+	Tracer Tracer
+
+	// StrictVirtualAccountNo rejects requests whose partnerServiceId is not 8 characters or
+	// whose virtualAccountNo is not partnerServiceId followed by customerNo, see FormatPartnerServiceID
+	StrictVirtualAccountNo bool
+
+	// TokenSignatureHash is the hash used for the RSA token request signature (default: crypto.SHA256).
+	// SHA-512 requires no extra import; other hashes must be linked into the binary.
+	TokenSignatureHash crypto.Hash
+
+	// PrivateKeyPassphrase decrypts PrivateKey when it is an encrypted PKCS#8
+	// ("ENCRYPTED PRIVATE KEY") or legacy encrypted PEM block
+	PrivateKeyPassphrase string
+
+	// SignatureAlgorithm selects the RSA scheme for the token signature (default: SignatureAlgorithmPKCS1v15)
+	SignatureAlgorithm SignatureAlgorithm
+
+	// ExtraHeaders are sent with every API request, e.g. an API gateway key required by a
+	// corporate proxy. Mandatory SNAP headers cannot be overridden. See also WithHeaders.
+	ExtraHeaders map[string]string
+
+	// DefaultCurrency, if set, rejects create, update and status update requests whose totalAmount
+	// uses another currency
+	DefaultCurrency string
+
+	// LogRedactFields are JSON fields and headers masked in debug logs, matched case-insensitively
+	// (default: virtualAccountName and accessToken). Authorization and X-SIGNATURE are always masked.
+	LogRedactFields []string
+
+	// LenientReportDecode skips malformed report transactions instead of failing the whole decode.
+	// Skipped entries are reported via VirtualAccountReportResponse.DecodeErrors.
+	LenientReportDecode bool
 }
 
 // Client represents the BRI Virtual Account API client
@@ -62,6 +276,8 @@ type Client struct {
 	httpClient   HTTPClient
 	auth         Authenticator
 	baseURL      string
+	baseURLErr   error
+	customURL    bool
 	partnerID    string
 	clientID     string
 	clientSecret string
@@ -70,8 +286,80 @@ type Client struct {
 	isSandbox    bool
 	debug        bool
 	logger       *slog.Logger
-	accessToken  string
-	tokenExpiry  time.Time
+
+	// tokenMu guards the token fields below; refreshMu serializes token refreshes
+	tokenMu     sync.RWMutex
+	refreshMu   sync.Mutex
+	accessToken string
+	tokenType   string
+	tokenExpiry time.Time
+	tokenIssued time.Time
+
+	tokenRefreshSkew    time.Duration
+	tokenExpiryUnit     TokenExpiryUnit
+	requireVAData       bool
+	lenientReportDecode bool
+	randReader          io.Reader
+	randMu              sync.Mutex
+	timestampMu         sync.Mutex
+	lastTimestamp       time.Time
+	requestSem          chan struct{}
+	onRequestSigned     func(SignedRequest)
+	expectedEnvironment Environment
+	verifyAfterCreate   bool
+	retryBudget         *retryBudget
+	retryPolicy         RetryPolicy
+
+	regenerateTrxIDOnConflict bool
+	canonicalJSON             bool
+	clock                     func() time.Time
+	maxReportSpan             time.Duration
+	signer                    func(method, path, accessToken, body, timestamp string) (string, error)
+	treatAsSuccess            map[string]bool
+	onTokenRefresh            func(token string, expiry time.Time)
+	metricsObserver           MetricsObserver
+	tracer                    Tracer
+	strictVirtualAccountNo    bool
+	tokenSignatureHash        crypto.Hash
+	privateKeyPassphrase      string
+	signatureAlgorithm        SignatureAlgorithm
+	extraHeaders              map[string]string
+	defaultCurrency           string
+	logRedactions             map[string]bool
+
+	// The parsed private key, cached on first use by signingKey
+	keyOnce   sync.Once
+	rsaKey    *rsa.PrivateKey
+	rsaKeyErr error
+
+	tokenExpiredRetries atomic.Int64
+}
+
+// SignedRequest describes a request as it was signed and sent
+type SignedRequest struct {
+	Method    string
+	Path      string
+	Body      []byte // Serialized body, identical to what was hashed and transmitted
+	Signature string
+	Header    http.Header // Copy of the headers as sent
+}
+
+// ClientStats holds operational counters collected by the client
+type ClientStats struct {
+	TokenExpiredRetries  int64 // Requests replayed after a token-expired response forced a refresh
+	RetryBudgetRemaining int   // Retries currently available; -1 when no retry budget is configured
+}
+
+// Stats returns a snapshot of the client's operational counters
+func (c *Client) Stats() ClientStats {
+	stats := ClientStats{
+		TokenExpiredRetries:  c.tokenExpiredRetries.Load(),
+		RetryBudgetRemaining: -1,
+	}
+	if c.retryBudget != nil {
+		stats.RetryBudgetRemaining = c.retryBudget.remaining()
+	}
+	return stats
 }
 
 // NewClient creates a new BRI Virtual Account API client
@@ -99,10 +387,17 @@ func NewClient(config Config) *Client {
 	if config.IsSandbox {
 		baseURL = sandboxBaseURL
 	}
+	var baseURLErr error
+	if config.BaseURL != "" {
+		baseURL = strings.TrimSuffix(config.BaseURL, "/")
+		baseURLErr = validateBaseURL(baseURL)
+	}
 
 	client := &Client{
 		httpClient:   httpClient,
 		baseURL:      baseURL,
+		baseURLErr:   baseURLErr,
+		customURL:    config.BaseURL != "",
 		partnerID:    config.PartnerID,
 		clientID:     config.ClientID,
 		clientSecret: config.ClientSecret,
@@ -110,6 +405,46 @@ func NewClient(config Config) *Client {
 		channelID:    config.ChannelID,
 		isSandbox:    config.IsSandbox,
 		debug:        config.Debug,
+
+		tokenRefreshSkew:    config.TokenRefreshSkew,
+		tokenExpiryUnit:     config.TokenExpiryUnit,
+		requireVAData:       config.RequireVAData,
+		lenientReportDecode: config.LenientReportDecode,
+		randReader:          config.RandReader,
+		onRequestSigned:     config.OnRequestSigned,
+		expectedEnvironment: config.ExpectedEnvironment,
+		verifyAfterCreate:   config.VerifyAfterCreate,
+		retryPolicy:         config.RetryPolicy,
+
+		regenerateTrxIDOnConflict: config.RegenerateTrxIDOnConflict,
+		canonicalJSON:             config.CanonicalJSON,
+		clock:                     config.Clock,
+		maxReportSpan:             config.MaxReportSpan,
+		signer:                    config.Signer,
+		onTokenRefresh:            config.OnTokenRefresh,
+		metricsObserver:           config.MetricsObserver,
+		tracer:                    config.Tracer,
+		strictVirtualAccountNo:    config.StrictVirtualAccountNo,
+		tokenSignatureHash:        config.TokenSignatureHash,
+		privateKeyPassphrase:      config.PrivateKeyPassphrase,
+		signatureAlgorithm:        config.SignatureAlgorithm,
+		extraHeaders:              config.ExtraHeaders,
+		defaultCurrency:           config.DefaultCurrency,
+		logRedactions:             newLogRedactions(config.LogRedactFields),
+	}
+
+	if len(config.TreatAsSuccess) > 0 {
+		client.treatAsSuccess = make(map[string]bool, len(config.TreatAsSuccess))
+		for _, code := range config.TreatAsSuccess {
+			client.treatAsSuccess[code] = true
+		}
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		client.requestSem = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	if config.RetryBudget > 0 {
+		client.retryBudget = newRetryBudget(config.RetryBudget, config.RetryBudgetRefillRate, client.now)
 	}
 
 	// If a custom logger is provided, use it locally (do NOT change global slog.Default).
@@ -121,6 +456,13 @@ func NewClient(config Config) *Client {
 		client.logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	}
 
+	if err := client.CheckEnvironment(); err != nil {
+		client.logWarn("BRI environment misconfiguration", "error", err.Error())
+	}
+	if config.HTTPClient == nil && config.IsSandbox {
+		client.logWarn("TLS certificate verification is disabled for the sandbox; do not use this client in production", "baseURL", baseURL)
+	}
+
 	// Use provided authenticator or create default
 	if config.Authenticator != nil {
 		client.auth = config.Authenticator
@@ -131,6 +473,71 @@ func NewClient(config Config) *Client {
 	return client
 }
 
+// CheckEnvironment verifies that Config.BaseURL is valid and that the configured environment
+// matches Config.ExpectedEnvironment. A custom BaseURL cannot be matched against the known
+// URLs, so IsSandbox is compared instead. It returns nil when neither is configured.
+func (c *Client) CheckEnvironment() error {
+	if c.baseURLErr != nil {
+		return c.baseURLErr
+	}
+
+	var expectedURL string
+	switch c.expectedEnvironment {
+	case "":
+		return nil
+	case EnvironmentProduction:
+		expectedURL = productionBaseURL
+	case EnvironmentSandbox:
+		expectedURL = sandboxBaseURL
+	default:
+		return fmt.Errorf("unknown expected environment %q", c.expectedEnvironment)
+	}
+
+	if c.customURL {
+		if c.isSandbox != (c.expectedEnvironment == EnvironmentSandbox) {
+			return fmt.Errorf("expected %s environment but IsSandbox is %t for custom base URL %s", c.expectedEnvironment, c.isSandbox, c.baseURL)
+		}
+		return nil
+	}
+	if c.baseURL != expectedURL {
+		return fmt.Errorf("expected %s environment (%s) but client targets %s", c.expectedEnvironment, expectedURL, c.baseURL)
+	}
+	return nil
+}
+
+// validateBaseURL checks that a custom base URL is absolute
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBaseURL, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("%w: %q is not an absolute URL", ErrInvalidBaseURL, baseURL)
+	}
+	return nil
+}
+
+// logWarn logs a warning using the client logger, or the default logger if none is set
+func (c *Client) logWarn(msg string, args ...any) {
+	if c.logger != nil {
+		c.logger.Warn(msg, args...)
+	} else {
+		slog.Warn(msg, args...)
+	}
+}
+
+// logDebug logs a debug message when debug is enabled and ctx does not suppress logs
+func (c *Client) logDebug(ctx context.Context, msg string, args ...any) {
+	if !c.debug || logsSuppressed(ctx) {
+		return
+	}
+	if c.logger != nil {
+		c.logger.Debug(msg, args...)
+	} else {
+		slog.Debug(msg, args...)
+	}
+}
+
 // DefaultAuthenticator implements the Authenticator interface
 type DefaultAuthenticator struct {
 	client *Client
@@ -138,34 +545,132 @@ type DefaultAuthenticator struct {
 
 // Authenticate performs OAuth2 authentication to get access token
 func (a *DefaultAuthenticator) Authenticate(ctx context.Context) error {
+	a.client.refreshMu.Lock()
+	defer a.client.refreshMu.Unlock()
+
 	return a.client.authenticate(ctx)
 }
 
 // IsAuthenticated checks if the client has a valid access token
 func (a *DefaultAuthenticator) IsAuthenticated() bool {
-	return a.client.accessToken != "" && time.Now().Before(a.client.tokenExpiry)
+	a.client.tokenMu.RLock()
+	defer a.client.tokenMu.RUnlock()
+
+	return a.client.accessToken != "" && a.client.now().Add(a.client.tokenRefreshSkew).Before(a.client.tokenExpiry)
 }
 
-// EnsureAuthenticated ensures the client has a valid access token
+// EnsureAuthenticated ensures the client has a valid access token.
+// Concurrent callers wait for a single refresh and reuse its token.
 func (a *DefaultAuthenticator) EnsureAuthenticated(ctx context.Context) error {
-	if !a.IsAuthenticated() {
-		return a.Authenticate(ctx)
+	if a.IsAuthenticated() {
+		return nil
 	}
-	return nil
+
+	a.client.refreshMu.Lock()
+	defer a.client.refreshMu.Unlock()
+
+	// Another goroutine may have refreshed the token while we waited
+	if a.IsAuthenticated() {
+		return nil
+	}
+	return a.client.authenticate(ctx)
 }
 
 // generateExternalID generates a random 9-digit external ID
 func (c *Client) generateExternalID() string {
-	return fmt.Sprintf("%09d", rand.Intn(999999999))
+	var buf [8]byte
+	var err error
+	if c.randReader != nil {
+		// Custom readers are not assumed to be safe for concurrent use
+		c.randMu.Lock()
+		_, err = io.ReadFull(c.randReader, buf[:])
+		c.randMu.Unlock()
+	} else {
+		_, err = io.ReadFull(rand.Reader, buf[:])
+	}
+	if err != nil {
+		// Fall back to the clock so a request can still be sent
+		return fmt.Sprintf("%09d", time.Now().UnixNano()%1000000000)
+	}
+	return fmt.Sprintf("%09d", binary.BigEndian.Uint64(buf[:])%1000000000)
 }
 
-// generateTimestamp generates current timestamp in ISO 8601 format
+// now returns the current time from the configured clock
+func (c *Client) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
+
+// sleep waits for d or until ctx is done. With a Config.Clock the wait also ends once the
+// clock has advanced by d, so a test clock can skip backoff without real delays.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	var tick <-chan time.Time
+	var deadline time.Time
+	if c.clock != nil {
+		deadline = c.now().Add(d)
+		ticker := time.NewTicker(clockPollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-tick:
+			if !c.now().Before(deadline) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// generateTimestamp generates current timestamp in ISO 8601 format.
+// Timestamps never go backwards: if the clock jumps back, the last timestamp is
+// bumped by a millisecond, the resolution of the X-TIMESTAMP format.
 func (c *Client) generateTimestamp() string {
-	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	now := c.now().UTC()
+
+	c.timestampMu.Lock()
+	if now.Before(c.lastTimestamp) {
+		now = c.lastTimestamp.Add(time.Millisecond)
+	}
+	c.lastTimestamp = now
+	c.timestampMu.Unlock()
+
+	return now.Format(timestampLayout)
 }
 
-// calculateSignature calculates HMAC-SHA512 signature for API requests
-func (c *Client) calculateSignature(httpMethod, requestPath, requestBody string) (string, error) {
+// requestTimestamp returns the timestamp pinned with WithTimestamp, or the current time
+func (c *Client) requestTimestamp(ctx context.Context) string {
+	if t, ok := timestampFromContext(ctx); ok {
+		return t.Format(timestampLayout)
+	}
+	return c.generateTimestamp()
+}
+
+// calculateSignature calculates HMAC-SHA512 signature for API requests.
+// The caller must hold c.tokenMu for reading when the client is shared.
+func (c *Client) calculateSignature(httpMethod, requestPath, requestBody, timestamp string) (string, error) {
+	if c.signer != nil {
+		return c.signer(httpMethod, requestPath, c.accessToken, requestBody, timestamp)
+	}
+
+	payload := stringToSign(httpMethod, requestPath, c.accessToken, requestBody, timestamp)
+
+	// Calculate HMAC-SHA512
+	return SignHMAC(c.clientSecret, payload), nil
+}
+
+// stringToSign builds the signature payload METHOD:path:token:sha256(body):timestamp
+func stringToSign(httpMethod, requestPath, accessToken, requestBody, timestamp string) string {
 	// Parse request body if present
 	var bodyStr string
 	if httpMethod != "GET" && requestBody != "" {
@@ -181,32 +686,361 @@ func (c *Client) calculateSignature(httpMethod, requestPath, requestBody string)
 	}
 
 	// Create signature payload
-	timestamp := c.generateTimestamp()
-	payload := fmt.Sprintf("%s:%s:%s:%s:%s",
-		httpMethod, requestPath, c.accessToken, payloadHash, timestamp)
+	return fmt.Sprintf("%s:%s:%s:%s:%s",
+		httpMethod, requestPath, accessToken, payloadHash, timestamp)
+}
 
-	// Calculate HMAC-SHA512
-	h := hmac.New(sha512.New, []byte(c.clientSecret))
-	h.Write([]byte(payload))
-	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+// HealthCheck verifies connectivity and credentials by requesting a fresh access token.
+// Debug logging is suppressed so frequent checks do not flood the logs.
+func (c *Client) HealthCheck(ctx context.Context) error {
+	if err := c.auth.Authenticate(WithSuppressLogs(ctx)); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
+
+// Refresh obtains a new access token even if the current one is still valid and returns
+// its expiry, e.g. for schedulers that refresh ahead of time
+func (c *Client) Refresh(ctx context.Context) (time.Time, error) {
+	if err := c.auth.Authenticate(ctx); err != nil {
+		return time.Time{}, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.tokenExpiry, nil
+}
+
+// AuthorizationHeader ensures the client is authenticated and returns the Authorization
+// header value for the cached token (e.g. "Bearer <token>"), for tooling that sends raw requests
+func (c *Client) AuthorizationHeader(ctx context.Context) (string, error) {
+	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
+		return "", fmt.Errorf("authentication failed: %w", err)
+	}
+
+	c.tokenMu.RLock()
+	authHeader := c.authorizationHeader()
+	c.tokenMu.RUnlock()
 
-	return signature, nil
+	if authHeader == "" {
+		return "", fmt.Errorf("no access token available after authentication")
+	}
+	return authHeader, nil
+}
+
+// authorizationHeader builds the Authorization header value from the stored token type.
+// It returns an empty string when there is no access token.
+// The caller must hold c.tokenMu for reading when the client is shared.
+func (c *Client) authorizationHeader() string {
+	if c.accessToken == "" {
+		return ""
+	}
+
+	scheme := strings.TrimSpace(c.tokenType)
+	if scheme == "" || strings.EqualFold(scheme, "bearer") {
+		scheme = "Bearer"
+	}
+	return scheme + " " + c.accessToken
 }
 
-// makeRequest makes an HTTP request with proper authentication
+// makeRequest makes an HTTP request with proper authentication.
+// If BRI reports the access token as expired, the token is refreshed and the request is replayed once.
+// A timeout set with WithRequestTimeout bounds the whole call, including reading the response body.
 func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var span Span
+	if c.tracer != nil {
+		ctx, span = c.tracer.Start(ctx, path)
+		defer span.End()
+	}
+
+	start := time.Now()
+	resp, err := c.makeRequestWithTimeout(ctx, method, path, body)
+	if c.metricsObserver != nil {
+		c.observeRequest(path, resp, time.Since(start))
+	}
+	if span != nil {
+		annotateSpan(span, method, resp, err)
+	}
+	return resp, err
+}
+
+// makeRequestWithTimeout applies the WithRequestTimeout deadline to makeRequestWithContext
+func (c *Client) makeRequestWithTimeout(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	timeout, ok := requestTimeoutFromContext(ctx)
+	if !ok {
+		return c.makeRequestWithContext(ctx, method, path, body)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	resp, err := c.makeRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Keep the context alive until the caller is done with the body
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody cancels a request context when the response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels its request context
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// makeRequestWithContext serializes the body, sends the request and handles token expiry
+func (c *Client) makeRequestWithContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	// Serialize body if present
-	var bodyBytes []byte
-	var bodyStr string
-	if body != nil {
-		bodyBytes, _ = json.Marshal(body)
-		bodyStr = string(bodyBytes)
+	bodyBytes, err := c.marshalBody(body)
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate signature
-	signature, err := c.calculateSignature(method, path, bodyStr)
+	c.tokenMu.RLock()
+	sentToken := c.accessToken
+	c.tokenMu.RUnlock()
+
+	resp, err := c.sendWithRetry(ctx, method, path, bodyBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate signature: %w", err)
+		return nil, err
+	}
+
+	if c.auth == nil || ctx.Err() != nil || !isTokenExpiredResponse(resp) || !c.allowRetry() {
+		return resp, nil
+	}
+
+	// Token expired mid-flight: re-authenticate and replay once.
+	// The replay is not checked again, so a second expiry is returned to the caller.
+	resp.Body.Close()
+	if err := c.refreshRejectedToken(ctx, sentToken); err != nil {
+		return nil, fmt.Errorf("failed to refresh expired token: %w", err)
+	}
+	c.tokenExpiredRetries.Add(1)
+
+	return c.sendWithRetry(ctx, method, path, bodyBytes)
+}
+
+// refreshRejectedToken re-authenticates after BRI rejected the token sent with a request as expired.
+// Concurrent callers rejected with the same token share a single refresh; a token another
+// call has already refreshed is kept and reused.
+func (c *Client) refreshRejectedToken(ctx context.Context, rejected string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.tokenMu.Lock()
+	if c.accessToken != rejected {
+		c.tokenMu.Unlock()
+		return nil
+	}
+	c.accessToken = ""
+	c.tokenMu.Unlock()
+
+	// The default authenticator takes refreshMu itself, so refresh directly while holding it
+	if _, ok := c.auth.(*DefaultAuthenticator); ok {
+		return c.authenticate(ctx)
+	}
+	return c.auth.Authenticate(ctx)
+}
+
+// marshalBody serializes a request body, canonicalizing it when CanonicalJSON is set.
+// The returned bytes are both signed and transmitted. A nil body yields nil bytes.
+func (c *Client) marshalBody(body interface{}) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, &MarshalError{Err: err}
+	}
+	if !c.canonicalJSON {
+		return minifyJSON(bodyBytes), nil
+	}
+
+	if bodyBytes, err = canonicalizeJSON(bodyBytes); err != nil {
+		return nil, &MarshalError{Err: err}
+	}
+	return bodyBytes, nil
+}
+
+// minifyJSON strips insignificant whitespace so the hashed and transmitted body is compact.
+// Invalid JSON is returned unchanged.
+func minifyJSON(data []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
+
+// canonicalizeJSON re-encodes JSON with object keys sorted at every level.
+// Numbers are preserved exactly as encoded.
+func canonicalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	// encoding/json writes map keys in sorted order
+	return json.Marshal(value)
+}
+
+// isTokenExpiredResponse checks whether the response reports an expired access token.
+// The response body is restored so the caller can still read it.
+func isTokenExpiredResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	respBodyBytes, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewBuffer(respBodyBytes))
+
+	var errorResp ErrorResponse
+	json.Unmarshal(respBodyBytes, &errorResp)
+	return errorResp.ResponseCode == tokenExpiredResponseCode
+}
+
+// requestSignature holds the values signRequest signed a request with
+type requestSignature struct {
+	timestamp   string
+	signature   string
+	externalID  string
+	accessToken string
+}
+
+// signRequest signs a request and sets all headers it is sent with: extra headers, the
+// signed SNAP headers and trace context. The signature covers the same timestamp sent in
+// X-TIMESTAMP, and signing and authorization use one consistent token snapshot.
+func (c *Client) signRequest(ctx context.Context, header http.Header, method, path string, bodyBytes []byte) (requestSignature, error) {
+	timestamp := c.requestTimestamp(ctx)
+	c.tokenMu.RLock()
+	accessToken := c.accessToken
+	signature, err := c.calculateSignature(method, path, string(bodyBytes), timestamp)
+	authHeader := c.authorizationHeader()
+	c.tokenMu.RUnlock()
+	if err != nil {
+		return requestSignature{}, err
+	}
+
+	externalID, ok := externalIDFromContext(ctx)
+	if !ok {
+		externalID = c.generateExternalID()
+	}
+
+	c.setExtraHeaders(ctx, header)
+	c.setSignedHeaders(ctx, header, externalID, signature, timestamp, authHeader)
+	if c.tracer != nil {
+		c.tracer.Inject(ctx, header)
+	}
+
+	return requestSignature{
+		timestamp:   timestamp,
+		signature:   signature,
+		externalID:  externalID,
+		accessToken: accessToken,
+	}, nil
+}
+
+// setSignedHeaders sets the SNAP headers sent with every signed request
+func (c *Client) setSignedHeaders(ctx context.Context, header http.Header, externalID, signature, timestamp, authHeader string) {
+	header.Set("Content-Type", "application/json")
+	header.Set("X-PARTNER-ID", c.requestPartnerID(ctx))
+	header.Set("X-EXTERNAL-ID", externalID)
+	header.Set("CHANNEL-ID", c.requestChannelID(ctx))
+	header.Set("X-SIGNATURE", signature)
+	header.Set("X-TIMESTAMP", timestamp)
+
+	if authHeader != "" {
+		header.Set("Authorization", authHeader)
+	}
+}
+
+// requestPartnerID returns the partner ID set with WithPartnerID, or the client default
+func (c *Client) requestPartnerID(ctx context.Context) string {
+	if id, ok := partnerIDFromContext(ctx); ok {
+		return id
+	}
+	return c.partnerID
+}
+
+// requestChannelID returns the channel ID set with WithChannelID, or the client default
+func (c *Client) requestChannelID(ctx context.Context) string {
+	if id, ok := channelIDFromContext(ctx); ok {
+		return id
+	}
+	return c.channelID
+}
+
+// setExtraHeaders sets Config.ExtraHeaders and headers added with WithHeaders, the latter
+// taking precedence. Mandatory SNAP headers are skipped so they cannot be overridden.
+func (c *Client) setExtraHeaders(ctx context.Context, header http.Header) {
+	perCall, _ := headersFromContext(ctx)
+	for _, extra := range []map[string]string{c.extraHeaders, perCall} {
+		for name, value := range extra {
+			if isSignedHeader(name) {
+				continue
+			}
+			header.Set(name, value)
+		}
+	}
+}
+
+// isSignedHeader reports whether name is one of the mandatory SNAP headers
+func isSignedHeader(name string) bool {
+	for _, signed := range signedHeaderNames {
+		if strings.EqualFold(name, signed) {
+			return true
+		}
+	}
+	return false
+}
+
+// signedHeaderNames lists the SNAP headers every API request must carry exactly once
+var signedHeaderNames = []string{"Content-Type", "Authorization", "X-PARTNER-ID", "X-EXTERNAL-ID", "CHANNEL-ID", "X-SIGNATURE", "X-TIMESTAMP"}
+
+// VerifyHeaders lists the required SNAP headers that are missing, duplicated or malformed
+// on req, e.g. as a pre-send assertion when requests pass through a proxy. An empty
+// result means the headers look valid; the signature itself is not recomputed.
+func (c *Client) VerifyHeaders(req *http.Request) []string {
+	var problems []string
+	for _, name := range signedHeaderNames {
+		switch values := req.Header.Values(name); {
+		case len(values) == 0 || strings.TrimSpace(values[0]) == "":
+			problems = append(problems, "missing "+name)
+		case len(values) > 1:
+			problems = append(problems, "duplicate "+name)
+		}
+	}
+
+	if v := req.Header.Get("Content-Type"); v != "" && !strings.HasPrefix(v, "application/json") {
+		problems = append(problems, "malformed Content-Type: expected application/json")
+	}
+	if v, want := req.Header.Get("X-PARTNER-ID"), c.requestPartnerID(req.Context()); v != "" && want != "" && v != want {
+		problems = append(problems, "malformed X-PARTNER-ID: does not match the configured partner ID")
+	}
+	if v := req.Header.Get("X-TIMESTAMP"); v != "" {
+		if _, err := time.Parse(timestampLayout, v); err != nil {
+			problems = append(problems, "malformed X-TIMESTAMP: expected "+timestampLayout)
+		}
+	}
+	return problems
+}
+
+// sendRequest signs and sends a single HTTP request
+func (c *Client) sendRequest(ctx context.Context, method, path string, bodyBytes []byte) (*http.Response, error) {
+	if c.baseURLErr != nil {
+		return nil, c.baseURLErr
 	}
 
 	// Create request
@@ -221,41 +1055,46 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set headers
-	timestamp := c.generateTimestamp()
-	externalID := c.generateExternalID()
+	// Wait for a request slot before signing so the timestamp is fresh when sent
+	if c.requestSem != nil {
+		select {
+		case c.requestSem <- struct{}{}:
+			defer func() { <-c.requestSem }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-PARTNER-ID", c.partnerID)
-	req.Header.Set("X-EXTERNAL-ID", externalID)
-	req.Header.Set("CHANNEL-ID", c.channelID)
-	req.Header.Set("X-SIGNATURE", signature)
-	req.Header.Set("X-TIMESTAMP", timestamp)
+	signed, err := c.signRequest(ctx, req.Header, method, path, bodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate signature: %w", err)
+	}
+	externalID := signed.externalID
 
-	if c.accessToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	correlationID, ok := correlationIDFromContext(ctx)
+	if !ok {
+		correlationID = externalID
 	}
 
-	// Debug logging - structured request (method/url/headers/body)
-	if c.debug {
-		// Prepare headers map copy
-		headersMap := map[string][]string{}
-		for k, v := range req.Header {
-			headersMap[k] = append([]string(nil), v...)
-		}
+	if c.onRequestSigned != nil {
+		c.onRequestSigned(SignedRequest{
+			Method:    method,
+			Path:      path,
+			Body:      append([]byte(nil), bodyBytes...),
+			Signature: signed.signature,
+			Header:    req.Header.Clone(),
+		})
+	}
 
-		// Prepare body for logging, truncate if too large
-		var bodyForLog string
-		if bodyBytes != nil {
-			if len(bodyBytes) > maxLogBodySize {
-				bodyForLog = string(bodyBytes[:maxLogBodySize]) + "... (truncated)"
-			} else {
-				bodyForLog = string(bodyBytes)
-			}
-		}
+	// Debug logging - structured request (method/url/headers/body)
+	if c.debug && !logsSuppressed(ctx) {
+		headersMap := c.headersForLog(req.Header)
+		bodyForLog := c.bodyForLog(bodyBytes)
 
 		if c.logger != nil {
 			c.logger.Debug("HTTP Request",
+				"externalID", externalID,
+				"correlationID", correlationID,
 				"method", req.Method,
 				"url", req.URL.String(),
 				"headers", headersMap,
@@ -263,6 +1102,8 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 			)
 		} else {
 			slog.Debug("HTTP Request",
+				"externalID", externalID,
+				"correlationID", correlationID,
 				"method", req.Method,
 				"url", req.URL.String(),
 				"headers", headersMap,
@@ -276,34 +1117,23 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 	resp, err := c.httpClient.Do(req)
 	duration := time.Since(start)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrNetwork, err)
 	}
 
 	// Debug logging - structured response (status/headers/body/duration)
-	if c.debug {
+	if c.debug && !logsSuppressed(ctx) {
 		// Read and restore response body so caller can still read it
 		respBodyBytes, _ := io.ReadAll(resp.Body)
 		// replace the body so it can be read by the caller
 		resp.Body = io.NopCloser(bytes.NewBuffer(respBodyBytes))
 
-		// Prepare headers map copy
-		respHeaders := map[string][]string{}
-		for k, v := range resp.Header {
-			respHeaders[k] = append([]string(nil), v...)
-		}
-
-		// Prepare body for logging, truncate if too large
-		var respBodyForLog string
-		if len(respBodyBytes) > 0 {
-			if len(respBodyBytes) > maxLogBodySize {
-				respBodyForLog = string(respBodyBytes[:maxLogBodySize]) + "... (truncated)"
-			} else {
-				respBodyForLog = string(respBodyBytes)
-			}
-		}
+		respHeaders := c.headersForLog(resp.Header)
+		respBodyForLog := c.bodyForLog(respBodyBytes)
 
 		if c.logger != nil {
 			c.logger.Debug("HTTP Response",
+				"externalID", externalID,
+				"correlationID", correlationID,
 				"status", resp.Status,
 				"statusCode", resp.StatusCode,
 				"headers", respHeaders,
@@ -312,6 +1142,8 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 			)
 		} else {
 			slog.Debug("HTTP Response",
+				"externalID", externalID,
+				"correlationID", correlationID,
 				"status", resp.Status,
 				"statusCode", resp.StatusCode,
 				"headers", respHeaders,
@@ -324,16 +1156,161 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 	return resp, nil
 }
 
-// parseErrorResponse parses an error response from the API
-func (c *Client) parseErrorResponse(respBody []byte, httpStatusCode int) *StructuredBRIAPIResponse {
+// Do sends a signed, authenticated request to an arbitrary SNAP endpoint.
+// It is an escape hatch for endpoints without a typed method; decode the result with DecodeResponse.
+// The caller must close the response body.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	// Ensure authentication
+	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	return c.makeRequest(ctx, method, path, body)
+}
+
+// DecodeResponse reads resp and decodes it into T.
+// A non-200 response is returned as a *StructuredBRIAPIResponse instead of an error;
+// the error is non-nil only when the body cannot be read or decoded.
+// The caller remains responsible for closing resp.Body.
+func DecodeResponse[T any](resp *http.Response) (*T, *StructuredBRIAPIResponse, error) {
+	return decodeResponse[T](resp, "SNAP", time.Now())
+}
+
+// decodeResponse implements DecodeResponse, naming the operation in error messages
+func decodeResponse[T any](resp *http.Response, name string, now time.Time) (*T, *StructuredBRIAPIResponse, error) {
+	return decodeResponseTreating[T](resp, name, nil, now)
+}
+
+// decodeResponseTreating is decodeResponse, except error responses whose code is in
+// treatAsSuccess are decoded into T like a successful response
+func decodeResponseTreating[T any](resp *http.Response, name string, treatAsSuccess map[string]bool, now time.Time) (*T, *StructuredBRIAPIResponse, error) {
+	respBody, apiErr, err := readResponse(resp, name, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if apiErr != nil && !treatAsSuccess[apiErr.ResponseCode] {
+		return nil, apiErr, nil
+	}
+
+	var result T
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		if apiErr != nil {
+			return nil, apiErr, nil
+		}
+		return nil, nil, newDecodeError(name, resp.StatusCode, respBody, err)
+	}
+
+	return &result, nil, nil
+}
+
+// readResponse reads the response body and parses non-200 responses into an API error
+// stamped with now
+func readResponse(resp *http.Response, name string, now time.Time) ([]byte, *StructuredBRIAPIResponse, error) {
+	// Read response
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s response: %w: %w", name, ErrNetwork, err)
+	}
+
+	// Parse response
+	if resp.StatusCode != http.StatusOK {
+		return respBody, parseErrorResponse(resp, respBody, now), nil
+	}
+
+	return respBody, nil, nil
+}
+
+// parseErrorResponse parses an error response from the API received at now
+func parseErrorResponse(resp *http.Response, respBody []byte, now time.Time) *StructuredBRIAPIResponse {
 	var errorResp ErrorResponse
 	json.Unmarshal(respBody, &errorResp)
 	return &StructuredBRIAPIResponse{
 		ResponseCode:    errorResp.ResponseCode,
 		ResponseMessage: errorResp.ResponseMessage,
-		HTTPStatusCode:  httpStatusCode,
-		Timestamp:       time.Now(),
+		HTTPStatusCode:  resp.StatusCode,
+		Timestamp:       now,
+		RetryAfter:      parseRetryAfter(resp.Header.Get("Retry-After"), now),
+		RateLimit:       parseRateLimit(resp.Header, now),
+	}
+}
+
+// parseRateLimit parses X-RateLimit-* headers, returning nil when none are present.
+// X-RateLimit-Reset may be given in seconds from now or as a Unix timestamp.
+func parseRateLimit(header http.Header, now time.Time) *RateLimitInfo {
+	limit := header.Get("X-RateLimit-Limit")
+	remaining := header.Get("X-RateLimit-Remaining")
+	reset := header.Get("X-RateLimit-Reset")
+	if limit == "" && remaining == "" && reset == "" {
+		return nil
+	}
+
+	info := &RateLimitInfo{}
+	info.Limit, _ = strconv.Atoi(limit)
+	info.Remaining, _ = strconv.Atoi(remaining)
+	if seconds, err := strconv.ParseInt(reset, 10, 64); err == nil {
+		// Values this large can only be timestamps, not a window length
+		if seconds > 1000000000 {
+			info.Reset = time.Unix(seconds, 0)
+		} else {
+			info.Reset = now.Add(time.Duration(seconds) * time.Second)
+		}
 	}
+	return info
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date relative to now
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// checkVAData enforces the RequireVAData policy on a successful response
+func (c *Client) checkVAData(responseCode string, data *VirtualAccountData) error {
+	if !c.requireVAData || data != nil {
+		return nil
+	}
+	if !NewStructuredBRIAPIResponse(responseCode, "").IsSuccess() {
+		return nil
+	}
+	return fmt.Errorf("%w (responseCode: %s)", ErrMissingVAData, responseCode)
+}
+
+// TokenInfo describes the current access token for audit logging. The token itself is redacted.
+type TokenInfo struct {
+	AccessToken string        // "[REDACTED]" when a token is held, empty otherwise
+	TokenType   string        // Token type reported by BRI
+	ExpiresIn   time.Duration // Lifetime reported by BRI
+	IssuedAt    time.Time     // When the token was obtained
+	ExpiresAt   time.Time     // When the token expires
+}
+
+// TokenInfo returns metadata about the current access token
+func (c *Client) TokenInfo() TokenInfo {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+
+	info := TokenInfo{
+		TokenType: c.tokenType,
+		IssuedAt:  c.tokenIssued,
+		ExpiresAt: c.tokenExpiry,
+	}
+	if c.accessToken != "" {
+		info.AccessToken = redactedToken
+	}
+	if !c.tokenIssued.IsZero() {
+		info.ExpiresIn = c.tokenExpiry.Sub(c.tokenIssued)
+	}
+	return info
 }
 
 // AuthResponse represents the OAuth2 token response
@@ -341,6 +1318,10 @@ type AuthResponse struct {
 	AccessToken string `json:"accessToken"`
 	TokenType   string `json:"tokenType"`
 	ExpiresIn   string `json:"expiresIn"`
+
+	// Set by BRI on failures, including some answered with HTTP 200
+	ResponseCode    string `json:"responseCode,omitempty"`
+	ResponseMessage string `json:"responseMessage,omitempty"`
 }
 
 // ErrorResponse represents an error response from the API
@@ -0,0 +1,141 @@
+package gobriva
+
+import (
+	"context"
+	"time"
+)
+
+// contextKey is the type of context keys defined by this package
+type contextKey int
+
+const (
+	timestampContextKey contextKey = iota
+	suppressLogsContextKey
+	requestTimeoutContextKey
+	externalIDContextKey
+	headersContextKey
+	retryHistoryContextKey
+	channelIDContextKey
+	partnerIDContextKey
+	idempotentContextKey
+	correlationIDContextKey
+)
+
+// WithTimestamp pins the signature and X-TIMESTAMP of requests made with ctx to t.
+// Intended for reproducing a signature exactly when debugging or replaying a request.
+func WithTimestamp(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, timestampContextKey, t)
+}
+
+// timestampFromContext returns the timestamp pinned with WithTimestamp, if any
+func timestampFromContext(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(timestampContextKey).(time.Time)
+	return t, ok
+}
+
+// WithSuppressLogs disables debug logging of requests made with ctx, e.g. for frequent health checks
+func WithSuppressLogs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, suppressLogsContextKey, true)
+}
+
+// logsSuppressed reports whether ctx was created with WithSuppressLogs
+func logsSuppressed(ctx context.Context) bool {
+	suppressed, _ := ctx.Value(suppressLogsContextKey).(bool)
+	return suppressed
+}
+
+// WithRequestTimeout bounds each API call made with ctx to d. The HTTP client's Timeout
+// still applies, so set Config.Timeout to the longest budget any call needs.
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutContextKey, d)
+}
+
+// requestTimeoutFromContext returns the timeout set with WithRequestTimeout, if any
+func requestTimeoutFromContext(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(requestTimeoutContextKey).(time.Duration)
+	return d, ok && d > 0
+}
+
+// WithExternalID sends id as X-EXTERNAL-ID on requests made with ctx, including retries.
+// BRI treats the external ID as an idempotency key for the day, so reuse one ID per
+// logical operation to retry it safely.
+func WithExternalID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, externalIDContextKey, id)
+}
+
+// externalIDFromContext returns the external ID set with WithExternalID, if any
+func externalIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(externalIDContextKey).(string)
+	return id, ok && id != ""
+}
+
+// WithHeaders adds headers to requests made with ctx, overriding Config.ExtraHeaders.
+// Mandatory SNAP headers such as X-SIGNATURE cannot be overridden.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return context.WithValue(ctx, headersContextKey, headers)
+}
+
+// headersFromContext returns the headers added with WithHeaders, if any
+func headersFromContext(ctx context.Context) (map[string]string, bool) {
+	headers, ok := ctx.Value(headersContextKey).(map[string]string)
+	return headers, ok
+}
+
+// WithRetryHistory returns a context whose calls record retried errors in the returned history
+func WithRetryHistory(ctx context.Context) (context.Context, *RetryHistory) {
+	history := &RetryHistory{}
+	return context.WithValue(ctx, retryHistoryContextKey, history), history
+}
+
+// retryHistoryFromContext returns the history attached with WithRetryHistory, if any
+func retryHistoryFromContext(ctx context.Context) (*RetryHistory, bool) {
+	history, ok := ctx.Value(retryHistoryContextKey).(*RetryHistory)
+	return history, ok
+}
+
+// WithChannelID sends id as CHANNEL-ID on requests made with ctx instead of Config.ChannelID
+func WithChannelID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, channelIDContextKey, id)
+}
+
+// channelIDFromContext returns the channel ID set with WithChannelID, if any
+func channelIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(channelIDContextKey).(string)
+	return id, ok && id != ""
+}
+
+// WithPartnerID sends id as X-PARTNER-ID on requests made with ctx instead of Config.PartnerID
+func WithPartnerID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, partnerIDContextKey, id)
+}
+
+// partnerIDFromContext returns the partner ID set with WithPartnerID, if any
+func partnerIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(partnerIDContextKey).(string)
+	return id, ok && id != ""
+}
+
+// WithIdempotent overrides whether requests made with ctx are retried automatically per
+// Config.RetryPolicy. By default inquiries and reports are retried; creates, updates and
+// deletes only with an external ID pinned by WithExternalID; status updates never.
+func WithIdempotent(ctx context.Context, idempotent bool) context.Context {
+	return context.WithValue(ctx, idempotentContextKey, idempotent)
+}
+
+// idempotentFromContext returns the override set with WithIdempotent, if any
+func idempotentFromContext(ctx context.Context) (bool, bool) {
+	idempotent, ok := ctx.Value(idempotentContextKey).(bool)
+	return idempotent, ok
+}
+
+// WithCorrelationID tags the debug logs of requests made with ctx with id, e.g. an
+// application request ID. Without it, logs are tagged with the X-EXTERNAL-ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey, id)
+}
+
+// correlationIDFromContext returns the correlation ID set with WithCorrelationID, if any
+func correlationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDContextKey).(string)
+	return id, ok && id != ""
+}
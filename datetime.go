@@ -0,0 +1,50 @@
+package gobriva
+
+import (
+	"fmt"
+	"time"
+)
+
+// briLocation is the fixed WIB (UTC+7) zone assumed for BRI datetimes without an offset
+var briLocation = time.FixedZone("WIB", 7*60*60)
+
+// briDateTimeLayouts lists the datetime variants BRI returns in fields such as trxDateTime and expiredDate
+var briDateTimeLayouts = []struct {
+	layout  string
+	hasZone bool
+}{
+	{time.RFC3339Nano, true},               // 2024-01-01T10:00:00+07:00, 2024-01-01T10:00:00.000Z
+	{"2006-01-02T15:04:05.000Z0700", true}, // 2024-01-01T10:00:00.000+0700
+	{"2006-01-02T15:04:05Z0700", true},     // 2024-01-01T10:00:00+0700
+	{"2006-01-02 15:04:05Z07:00", true},    // 2024-01-01 10:00:00+07:00
+	{"2006-01-02T15:04:05.000", false},     // 2024-01-01T10:00:00.000
+	{"2006-01-02T15:04:05", false},         // 2024-01-01T10:00:00
+	{"2006-01-02 15:04:05", false},         // 2024-01-01 10:00:00
+}
+
+// ParseBRIDateTime parses a datetime in any of the known BRI formats.
+// Values without a timezone offset are interpreted as WIB (UTC+7).
+func ParseBRIDateTime(s string) (time.Time, error) {
+	for _, l := range briDateTimeLayouts {
+		var t time.Time
+		var err error
+		if l.hasZone {
+			t, err = time.Parse(l.layout, s)
+		} else {
+			t, err = time.ParseInLocation(l.layout, s, briLocation)
+		}
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized BRI datetime format: %q", s)
+}
+
+// NormalizeBRIDateTime converts a BRI datetime string to canonical RFC3339
+func NormalizeBRIDateTime(s string) (string, error) {
+	t, err := ParseBRIDateTime(s)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339), nil
+}
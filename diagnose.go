@@ -0,0 +1,73 @@
+package gobriva
+
+import (
+	"fmt"
+	"time"
+)
+
+// minRecommendedTimeout is the shortest Config.Timeout not reported as suspicious by Diagnose
+const minRecommendedTimeout = 5 * time.Second
+
+// DiagnosticSeverity classifies a Diagnostic
+type DiagnosticSeverity string
+
+const (
+	DiagnosticError   DiagnosticSeverity = "error"   // The client will not work
+	DiagnosticWarning DiagnosticSeverity = "warning" // The client works but the setting is risky
+)
+
+// Diagnostic describes one configuration problem found by Config.Diagnose
+type Diagnostic struct {
+	Severity DiagnosticSeverity
+	Field    string // Config field the diagnostic is about
+	Message  string // Actionable description of the problem
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Severity, d.Field, d.Message)
+}
+
+// Diagnose checks the configuration without contacting BRI and returns its problems,
+// e.g. for a startup health command. An empty result means no problems were found.
+func (c Config) Diagnose() []Diagnostic {
+	var diagnostics []Diagnostic
+	report := func(severity DiagnosticSeverity, field, message string) {
+		diagnostics = append(diagnostics, Diagnostic{Severity: severity, Field: field, Message: message})
+	}
+
+	if c.PartnerID == "" {
+		report(DiagnosticError, "PartnerID", "missing partner ID; set it to the X-PARTNER-ID issued by BRI")
+	}
+	if c.ChannelID == "" {
+		report(DiagnosticError, "ChannelID", "missing channel ID; every request sends it as CHANNEL-ID")
+	}
+	if c.ClientSecret == "" {
+		report(DiagnosticError, "ClientSecret", "missing client secret; request signatures cannot be computed")
+	}
+
+	// The key and client ID are only used by the default authenticator
+	if c.Authenticator == nil {
+		if c.ClientID == "" {
+			report(DiagnosticError, "ClientID", "missing client ID; access tokens cannot be requested")
+		}
+		if c.PrivateKey == "" {
+			report(DiagnosticError, "PrivateKey", "missing private key; access token requests cannot be signed")
+		} else if _, err := parseEncryptedRSAPrivateKey(c.PrivateKey, c.PrivateKeyPassphrase); err != nil {
+			report(DiagnosticError, "PrivateKey", fmt.Sprintf("private key cannot be parsed: %v; check the PEM block and PrivateKeyPassphrase", err))
+		}
+	}
+
+	if c.BaseURL != "" {
+		if err := validateBaseURL(c.BaseURL); err != nil {
+			report(DiagnosticError, "BaseURL", err.Error())
+		}
+	}
+	if c.IsSandbox && c.HTTPClient == nil {
+		report(DiagnosticWarning, "IsSandbox", "TLS certificate verification is disabled for the sandbox; do not use this configuration in production")
+	}
+	if c.Timeout > 0 && c.Timeout < minRecommendedTimeout {
+		report(DiagnosticWarning, "Timeout", fmt.Sprintf("timeout %s is shorter than %s; BRI calls may time out under normal latency", c.Timeout, minRecommendedTimeout))
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,46 @@
+package gobriva
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// reportCSVHeader is the column order written by WriteCSV
+var reportCSVHeader = []string{"partnerServiceId", "customerNo", "virtualAccountNo", "amount", "currency", "trxDateTime", "trxId"}
+
+// WriteCSV writes the report transactions as CSV with a header row, using the paid amount.
+// An empty report writes only the header.
+func (r *VirtualAccountReportResponse) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportCSVHeader); err != nil {
+		return err
+	}
+	for _, trx := range r.VirtualAccountData {
+		if err := cw.Write([]string{
+			trx.PartnerServiceID,
+			trx.CustomerNo,
+			trx.VirtualAccountNo,
+			trx.PaidAmount.Value,
+			trx.PaidAmount.Currency,
+			trx.TrxDateTime,
+			trx.TrxID,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report transactions as an indented JSON array.
+// An empty report writes an empty array.
+func (r *VirtualAccountReportResponse) WriteJSON(w io.Writer) error {
+	transactions := r.VirtualAccountData
+	if transactions == nil {
+		transactions = []VirtualAccountTransaction{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(transactions)
+}
@@ -0,0 +1,24 @@
+package gobriva
+
+import (
+	"net/http"
+	"time"
+)
+
+// MetricsObserver receives per-request metrics, e.g. to export them as Prometheus counters and histograms
+type MetricsObserver interface {
+	// ObserveRequest is called once per API call. Status is 0 and category is empty
+	// when no response was received.
+	ObserveRequest(operation string, status int, category HttpCategory, duration time.Duration)
+}
+
+// observeRequest reports a finished request to the metrics observer
+func (c *Client) observeRequest(path string, resp *http.Response, duration time.Duration) {
+	var status int
+	var category HttpCategory
+	if resp != nil {
+		status = resp.StatusCode
+		category = responseCategory(resp)
+	}
+	c.metricsObserver.ObserveRequest(operationName(path), status, category, duration)
+}
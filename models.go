@@ -1,6 +1,12 @@
 package gobriva
 
-import "fmt"
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Amount represents monetary amount with currency
 type Amount struct {
@@ -8,25 +14,75 @@ type Amount struct {
 	Currency string `json:"currency"`
 }
 
+// NewAmount creates an Amount with the value formatted to two decimals
+func NewAmount(value float64, currency string) Amount {
+	return Amount{
+		Value:    strconv.FormatFloat(value, 'f', 2, 64),
+		Currency: currency,
+	}
+}
+
+// Float64 parses the amount value as a float64
+func (a Amount) Float64() (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(a.Value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount value %q: %w", a.Value, err)
+	}
+	return value, nil
+}
+
+// BigRat parses the amount value as an exact rational for monetary math
+func (a Amount) BigRat() (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(a.Value))
+	if !ok {
+		return nil, fmt.Errorf("invalid amount value %q", a.Value)
+	}
+	return value, nil
+}
+
+// RequestOption sets optional fields on a request built by a New*Request constructor
+type RequestOption func(*requestOptions)
+
+// requestOptions collects the optional fields set by RequestOption values
+type requestOptions struct {
+	additionalInfo AdditionalInfo
+}
+
+// WithDescription sets the additionalInfo description of the request
+func WithDescription(description string) RequestOption {
+	return func(o *requestOptions) {
+		o.additionalInfo.Description = description
+	}
+}
+
+// applyRequestOptions applies opts in order
+func applyRequestOptions(opts []RequestOption) requestOptions {
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // NewCreateVirtualAccountRequest creates a new CreateVirtualAccountRequest with default values
-func NewCreateVirtualAccountRequest(partnerServiceID, customerNo, vaNo, vaName, trxID string, amount float64, currency, expiredDate string) *CreateVirtualAccountRequest {
+func NewCreateVirtualAccountRequest(partnerServiceID, customerNo, vaNo, vaName, trxID string, amount float64, currency, expiredDate string, opts ...RequestOption) *CreateVirtualAccountRequest {
+	o := applyRequestOptions(opts)
 	return &CreateVirtualAccountRequest{
 		PartnerServiceID:   partnerServiceID,
 		CustomerNo:         customerNo,
 		VirtualAccountNo:   vaNo,
 		VirtualAccountName: vaName,
-		TotalAmount: Amount{
-			Value:    fmt.Sprintf("%.2f", amount),
-			Currency: currency,
-		},
-		ExpiredDate: expiredDate,
-		TrxID:       trxID,
+		TotalAmount:        NewAmount(amount, currency),
+		ExpiredDate:        expiredDate,
+		TrxID:              trxID,
+		AdditionalInfo:     o.additionalInfo,
 	}
 }
 
 // AdditionalInfo represents additional information for VA
 type AdditionalInfo struct {
 	Description string `json:"description,omitempty"`
+	Channel     string `json:"channel,omitempty"` // Payment channel, required by some BRIVA setups
 }
 
 // CreateVirtualAccountRequest represents the request to create a virtual account
@@ -45,7 +101,7 @@ type CreateVirtualAccountRequest struct {
 type CreateVirtualAccountResponse struct {
 	ResponseCode       string              `json:"responseCode"`
 	ResponseMessage    string              `json:"responseMessage"`
-	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"` // nil if omitted by BRI, see Config.RequireVAData
 }
 
 // UpdateVirtualAccountRequest represents the request to update a virtual account
@@ -64,23 +120,25 @@ type UpdateVirtualAccountRequest struct {
 type UpdateVirtualAccountResponse struct {
 	ResponseCode       string              `json:"responseCode"`
 	ResponseMessage    string              `json:"responseMessage"`
-	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"` // nil if omitted by BRI, see Config.RequireVAData
 }
 
 // UpdateVirtualAccountStatusRequest represents the request to update VA status
 type UpdateVirtualAccountStatusRequest struct {
-	PartnerServiceID string `json:"partnerServiceId"`
-	CustomerNo       string `json:"customerNo"`
-	VirtualAccountNo string `json:"virtualAccountNo"`
-	TrxID            string `json:"trxId"`
-	PaidStatus       string `json:"paidStatus"`
+	PartnerServiceID string          `json:"partnerServiceId"`
+	CustomerNo       string          `json:"customerNo"`
+	VirtualAccountNo string          `json:"virtualAccountNo"`
+	TrxID            string          `json:"trxId"`
+	PaidStatus       string          `json:"paidStatus"`
+	TotalAmount      *Amount         `json:"totalAmount,omitempty"`    // Optional settled amount, for setups that expect it echoed
+	AdditionalInfo   *AdditionalInfo `json:"additionalInfo,omitempty"` // Optional, e.g. an audit note
 }
 
 // UpdateVirtualAccountStatusResponse represents the response from updating VA status
 type UpdateVirtualAccountStatusResponse struct {
 	ResponseCode       string              `json:"responseCode"`
 	ResponseMessage    string              `json:"responseMessage"`
-	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"` // nil if omitted by BRI, see Config.RequireVAData
 }
 
 // InquiryVirtualAccountStatusRequest represents the request for VA status inquiry
@@ -95,7 +153,7 @@ type InquiryVirtualAccountStatusRequest struct {
 type InquiryVirtualAccountStatusResponse struct {
 	ResponseCode       string              `json:"responseCode"`
 	ResponseMessage    string              `json:"responseMessage"`
-	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"` // nil if omitted by BRI, see Config.RequireVAData
 	AdditionalInfo     AdditionalInfo      `json:"additionalInfo,omitempty"`
 }
 
@@ -111,7 +169,7 @@ type InquiryVirtualAccountRequest struct {
 type InquiryVirtualAccountResponse struct {
 	ResponseCode       string              `json:"responseCode"`
 	ResponseMessage    string              `json:"responseMessage"`
-	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"` // nil if omitted by BRI, see Config.RequireVAData
 }
 
 // DeleteVirtualAccountRequest represents the request to delete a virtual account
@@ -126,7 +184,7 @@ type DeleteVirtualAccountRequest struct {
 type DeleteVirtualAccountResponse struct {
 	ResponseCode       string              `json:"responseCode"`
 	ResponseMessage    string              `json:"responseMessage"`
-	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"` // nil if omitted by BRI, see Config.RequireVAData
 }
 
 // VirtualAccountReportRequest represents the request for VA report
@@ -142,7 +200,27 @@ type VirtualAccountReportRequest struct {
 type VirtualAccountReportResponse struct {
 	ResponseCode       string                      `json:"responseCode"`
 	ResponseMessage    string                      `json:"responseMessage"`
-	VirtualAccountData []VirtualAccountTransaction `json:"virtualAccountData,omitempty"`
+	VirtualAccountData []VirtualAccountTransaction `json:"virtualAccountData,omitempty"` // Empty on success when there are no transactions
+
+	// DecodeErrors holds errors for transactions skipped in lenient decode mode
+	DecodeErrors []error `json:"-"`
+}
+
+// BalanceInquiryRequest represents the request for an account balance inquiry
+type BalanceInquiryRequest struct {
+	PartnerReferenceNo string `json:"partnerReferenceNo,omitempty"`
+	AccountNo          string `json:"accountNo"`
+}
+
+// BalanceInquiryResponse represents the response from an account balance inquiry
+type BalanceInquiryResponse struct {
+	ResponseCode       string `json:"responseCode"`
+	ResponseMessage    string `json:"responseMessage"`
+	ReferenceNo        string `json:"referenceNo,omitempty"`
+	PartnerReferenceNo string `json:"partnerReferenceNo,omitempty"`
+	AccountNo          string `json:"accountNo"`
+	Name               string `json:"name,omitempty"`
+	AvailableBalance   Amount `json:"availableBalance"` // Value and currency of the available balance
 }
 
 // VirtualAccountData represents virtual account information
@@ -155,8 +233,98 @@ type VirtualAccountData struct {
 	TrxID              string         `json:"trxId"`
 	TotalAmount        Amount         `json:"totalAmount,omitempty"`
 	ExpiredDate        string         `json:"expiredDate,omitempty"`
+	TrxDateTime        string         `json:"trxDateTime,omitempty"`       // When the VA transaction was created, see TrxDateTimeValue
+	TrxDateTimeExpire  string         `json:"trxDateTimeExpire,omitempty"` // When the VA transaction expires, see TrxDateTimeExpireValue
 	AdditionalInfo     AdditionalInfo `json:"additionalInfo,omitempty"`
-	PaidStatus         string         `json:"paidStatus,omitempty"`
+	PaidStatus         string         `json:"paidStatus,omitempty"` // Raw value as sent by BRI, see PaidStatusValue
+}
+
+// PaidStatus is the typed form of a VA's paidStatus
+type PaidStatus string
+
+const (
+	PaidStatusYes     PaidStatus = "Y"
+	PaidStatusNo      PaidStatus = "N"
+	PaidStatusUnknown PaidStatus = "Unknown" // Missing or not a value this SDK recognizes
+)
+
+// ParsePaidStatus maps a raw paidStatus to a PaidStatus; unrecognized values map to PaidStatusUnknown
+func ParsePaidStatus(raw string) PaidStatus {
+	switch PaidStatus(raw) {
+	case PaidStatusYes, PaidStatusNo:
+		return PaidStatus(raw)
+	default:
+		return PaidStatusUnknown
+	}
+}
+
+// PaidStatusValue returns the typed paidStatus. The raw value stays in PaidStatus.
+func (d *VirtualAccountData) PaidStatusValue() PaidStatus {
+	return ParsePaidStatus(d.PaidStatus)
+}
+
+// AmountEquals reports whether the VA's totalAmount numerically equals expected,
+// so "100000.00" equals "100000.0". Currencies must match when both are set.
+func (d *VirtualAccountData) AmountEquals(expected Amount) (bool, error) {
+	if d == nil {
+		return false, fmt.Errorf("virtual account data is nil")
+	}
+	return amountsEqual(d.TotalAmount, expected)
+}
+
+// ExpiredAtIn parses the VA's expiredDate and converts it to loc, e.g. to show
+// customers the payment deadline in the merchant's timezone
+func (d *VirtualAccountData) ExpiredAtIn(loc *time.Location) (time.Time, error) {
+	if d == nil {
+		return time.Time{}, fmt.Errorf("virtual account data is nil")
+	}
+	expiredAt, err := ParseBRIDateTime(d.ExpiredDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse expiredDate: %w", err)
+	}
+	return expiredAt.In(loc), nil
+}
+
+// TrxDateTimeValue parses trxDateTime; values without an offset are interpreted as WIB
+func (d *VirtualAccountData) TrxDateTimeValue() (time.Time, error) {
+	if d == nil {
+		return time.Time{}, fmt.Errorf("virtual account data is nil")
+	}
+	t, err := ParseBRIDateTime(d.TrxDateTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse trxDateTime: %w", err)
+	}
+	return t, nil
+}
+
+// TrxDateTimeExpireValue parses trxDateTimeExpire; values without an offset are interpreted as WIB
+func (d *VirtualAccountData) TrxDateTimeExpireValue() (time.Time, error) {
+	if d == nil {
+		return time.Time{}, fmt.Errorf("virtual account data is nil")
+	}
+	t, err := ParseBRIDateTime(d.TrxDateTimeExpire)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse trxDateTimeExpire: %w", err)
+	}
+	return t, nil
+}
+
+// amountsEqual compares two amounts numerically; currencies must match when both are set
+func amountsEqual(actual, expected Amount) (bool, error) {
+	actualValue, ok := new(big.Rat).SetString(strings.TrimSpace(actual.Value))
+	if !ok {
+		return false, fmt.Errorf("invalid actual amount value %q", actual.Value)
+	}
+	expectedValue, ok := new(big.Rat).SetString(strings.TrimSpace(expected.Value))
+	if !ok {
+		return false, fmt.Errorf("invalid expected amount value %q", expected.Value)
+	}
+
+	if actual.Currency != "" && expected.Currency != "" && !strings.EqualFold(actual.Currency, expected.Currency) {
+		return false, nil
+	}
+
+	return actualValue.Cmp(expectedValue) == 0, nil
 }
 
 // VirtualAccountTransaction represents a transaction in VA report
@@ -173,6 +341,8 @@ type VirtualAccountTransaction struct {
 	PaymentRequestID   string     `json:"paymentRequestId"`
 	TotalAmount        Amount     `json:"totalAmount"`
 	FreeTexts          []FreeText `json:"freeTexts,omitempty"`
+	ReferenceNo        string     `json:"referenceNo,omitempty"` // Bank-side reference for reconciliation
+	JournalNum         string     `json:"journalNum,omitempty"`  // Bank journal number for reconciliation
 }
 
 // FreeText represents free text information in multiple languages
@@ -196,33 +366,66 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("BRI API Error [%s]: %s", e.ResponseCode, e.ResponseMessage)
 }
 
+// MarshalError is returned when a request body cannot be serialized to JSON
+type MarshalError struct {
+	Err error
+}
+
+func (e *MarshalError) Error() string {
+	return fmt.Sprintf("failed to marshal request body: %v", e.Err)
+}
+
+// Unwrap returns the underlying marshal error
+func (e *MarshalError) Unwrap() error {
+	return e.Err
+}
+
 // Helper functions for creating requests
 
 // NewUpdateVirtualAccountRequest creates a new UpdateVirtualAccountRequest with default values
-func NewUpdateVirtualAccountRequest(partnerServiceID, customerNo, vaNo, vaName, trxID string, amount float64, currency, expiredDate string) *UpdateVirtualAccountRequest {
+func NewUpdateVirtualAccountRequest(partnerServiceID, customerNo, vaNo, vaName, trxID string, amount float64, currency, expiredDate string, opts ...RequestOption) *UpdateVirtualAccountRequest {
+	o := applyRequestOptions(opts)
 	return &UpdateVirtualAccountRequest{
 		PartnerServiceID:   partnerServiceID,
 		CustomerNo:         customerNo,
 		VirtualAccountNo:   vaNo,
 		VirtualAccountName: vaName,
-		TotalAmount: Amount{
-			Value:    fmt.Sprintf("%.2f", amount),
-			Currency: currency,
-		},
-		ExpiredDate: expiredDate,
-		TrxID:       trxID,
+		TotalAmount:        NewAmount(amount, currency),
+		ExpiredDate:        expiredDate,
+		TrxID:              trxID,
+		AdditionalInfo:     o.additionalInfo,
 	}
 }
 
 // NewUpdateVirtualAccountStatusRequest creates a new UpdateVirtualAccountStatusRequest
-func NewUpdateVirtualAccountStatusRequest(partnerServiceID, customerNo, vaNo, trxID, paidStatus string) *UpdateVirtualAccountStatusRequest {
-	return &UpdateVirtualAccountStatusRequest{
+func NewUpdateVirtualAccountStatusRequest(partnerServiceID, customerNo, vaNo, trxID, paidStatus string, opts ...RequestOption) *UpdateVirtualAccountStatusRequest {
+	req := &UpdateVirtualAccountStatusRequest{
 		PartnerServiceID: partnerServiceID,
 		CustomerNo:       customerNo,
 		VirtualAccountNo: vaNo,
 		TrxID:            trxID,
 		PaidStatus:       paidStatus,
 	}
+	// additionalInfo is a pointer here, so it is only sent when an option sets it
+	if o := applyRequestOptions(opts); o.additionalInfo != (AdditionalInfo{}) {
+		req.AdditionalInfo = &o.additionalInfo
+	}
+	return req
+}
+
+// NewUpdateVirtualAccountStatusRequestWithNote creates a new UpdateVirtualAccountStatusRequest
+// recording note as the additionalInfo description for auditing
+func NewUpdateVirtualAccountStatusRequestWithNote(partnerServiceID, customerNo, vaNo, trxID, paidStatus, note string) *UpdateVirtualAccountStatusRequest {
+	return NewUpdateVirtualAccountStatusRequest(partnerServiceID, customerNo, vaNo, trxID, paidStatus, WithDescription(note))
+}
+
+// NewUpdateVirtualAccountStatusRequestWithAmount creates a new UpdateVirtualAccountStatusRequest
+// echoing the settled amount as totalAmount
+func NewUpdateVirtualAccountStatusRequestWithAmount(partnerServiceID, customerNo, vaNo, trxID, paidStatus string, amount float64, currency string, opts ...RequestOption) *UpdateVirtualAccountStatusRequest {
+	req := NewUpdateVirtualAccountStatusRequest(partnerServiceID, customerNo, vaNo, trxID, paidStatus, opts...)
+	totalAmount := NewAmount(amount, currency)
+	req.TotalAmount = &totalAmount
+	return req
 }
 
 // NewInquiryVirtualAccountRequest creates a new InquiryVirtualAccountRequest
@@ -244,3 +447,10 @@ func NewVirtualAccountReportRequest(partnerServiceID, startDate, startTime, endT
 		EndTime:          endTime,
 	}
 }
+
+// NewBalanceInquiryRequest creates a new BalanceInquiryRequest
+func NewBalanceInquiryRequest(accountNo string) *BalanceInquiryRequest {
+	return &BalanceInquiryRequest{
+		AccountNo: accountNo,
+	}
+}
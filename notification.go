@@ -0,0 +1,137 @@
+package gobriva
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidSignature is returned when a payment notification's X-SIGNATURE does not verify
+var ErrInvalidSignature = errors.New("invalid payment notification signature")
+
+// PaymentNotification represents the payment callback BRI sends when a VA is paid
+type PaymentNotification struct {
+	PartnerServiceID   string         `json:"partnerServiceId"`
+	CustomerNo         string         `json:"customerNo"`
+	VirtualAccountNo   string         `json:"virtualAccountNo"`
+	VirtualAccountName string         `json:"virtualAccountName,omitempty"`
+	TrxID              string         `json:"trxId,omitempty"`
+	PaymentRequestID   string         `json:"paymentRequestId"`
+	PaidAmount         Amount         `json:"paidAmount"`
+	TotalAmount        Amount         `json:"totalAmount,omitempty"`
+	TrxDateTime        string         `json:"trxDateTime"`
+	ReferenceNo        string         `json:"referenceNo,omitempty"` // Bank-side reference for reconciliation
+	JournalNum         string         `json:"journalNum,omitempty"`  // Bank journal number for reconciliation
+	AdditionalInfo     AdditionalInfo `json:"additionalInfo,omitempty"`
+}
+
+// Matches reports whether the notification is for vaNumber and its paid amount
+// numerically equals expected. Surrounding whitespace in VA numbers is ignored.
+func (n *PaymentNotification) Matches(vaNumber string, expected Amount) (bool, error) {
+	if n == nil {
+		return false, fmt.Errorf("payment notification is nil")
+	}
+
+	if strings.TrimSpace(n.VirtualAccountNo) != strings.TrimSpace(vaNumber) {
+		return false, nil
+	}
+
+	return amountsEqual(n.PaidAmount, expected)
+}
+
+// ParsePaymentNotification verifies and decodes a payment callback from BRI.
+// The X-SIGNATURE header must be the HMAC-SHA512 signature of the request, computed with the
+// client secret over the same string-to-sign as outgoing requests, using the bearer token from
+// the Authorization header and the X-TIMESTAMP header. The request body is restored for the caller.
+func (c *Client) ParsePaymentNotification(r *http.Request) (*PaymentNotification, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment notification: %w", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	signature := r.Header.Get("X-SIGNATURE")
+	timestamp := r.Header.Get("X-TIMESTAMP")
+	if signature == "" || timestamp == "" {
+		return nil, fmt.Errorf("%w: missing X-SIGNATURE or X-TIMESTAMP header", ErrInvalidSignature)
+	}
+
+	accessToken := strings.TrimSpace(r.Header.Get("Authorization"))
+	if scheme, token, ok := strings.Cut(accessToken, " "); ok && strings.EqualFold(scheme, "bearer") {
+		accessToken = strings.TrimSpace(token)
+	}
+
+	payload := stringToSign(r.Method, r.URL.EscapedPath(), accessToken, string(body), timestamp)
+	if !VerifyHMAC(c.clientSecret, payload, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var notification PaymentNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment notification: %w: %w", ErrDecode, err)
+	}
+	return &notification, nil
+}
+
+// ToStatusInquiry builds a status inquiry for the notified virtual account, e.g. to confirm
+// the payment with BRI before fulfilling an order. It returns nil for a nil notification.
+func (n *PaymentNotification) ToStatusInquiry(inquiryRequestID string) *InquiryVirtualAccountStatusRequest {
+	if n == nil {
+		return nil
+	}
+
+	return &InquiryVirtualAccountStatusRequest{
+		PartnerServiceID: n.PartnerServiceID,
+		CustomerNo:       n.CustomerNo,
+		VirtualAccountNo: n.VirtualAccountNo,
+		InquiryRequestID: inquiryRequestID,
+	}
+}
+
+// PaymentNotificationResponse is the reply BRI expects to a payment notification
+type PaymentNotificationResponse struct {
+	ResponseCode       string              `json:"responseCode"`
+	ResponseMessage    string              `json:"responseMessage"`
+	VirtualAccountData *VirtualAccountData `json:"virtualAccountData,omitempty"`
+}
+
+// NewPaymentNotificationResponse creates a reply echoing the notified VA data
+func NewPaymentNotificationResponse(code, message string, data *VirtualAccountData) *PaymentNotificationResponse {
+	return &PaymentNotificationResponse{
+		ResponseCode:       code,
+		ResponseMessage:    message,
+		VirtualAccountData: data,
+	}
+}
+
+// StatusCode returns the HTTP status encoded in the first three digits of the response code,
+// or 500 if the code is malformed
+func (r *PaymentNotificationResponse) StatusCode() int {
+	rc, err := ParseBRIResponseCode(r.ResponseCode)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return rc.HTTPStatus
+}
+
+// WriteTo writes the JSON reply to w. When w is an http.ResponseWriter, the Content-Type
+// header and the status code derived from the response code are set first.
+func (r *PaymentNotificationResponse) WriteTo(w io.Writer) (int64, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal payment notification response: %w", err)
+	}
+
+	if rw, ok := w.(http.ResponseWriter); ok {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(r.StatusCode())
+	}
+
+	n, err := w.Write(body)
+	return int64(n), err
+}
@@ -0,0 +1,47 @@
+package gobriva
+
+import "context"
+
+// operation describes an API endpoint
+type operation struct {
+	name       string // Reported to a MetricsObserver
+	readOnly   bool   // Has no side effects, so it is always safe to retry
+	idempotent bool   // Safe to retry when X-EXTERNAL-ID is pinned with WithExternalID
+}
+
+// operations maps API paths to their operation. Creates, updates and deletes are only
+// replayed with a pinned external ID, because BRI deduplicates by X-EXTERNAL-ID and every
+// attempt otherwise gets a fresh one. Status updates change payment state and are never
+// retried by default.
+var operations = map[string]operation{
+	"/snap/v1.0/transfer-va/create-va":     {name: "CreateVirtualAccount", idempotent: true},
+	"/snap/v1.0/transfer-va/update-va":     {name: "UpdateVirtualAccount", idempotent: true},
+	"/snap/v1.0/transfer-va/update-status": {name: "UpdateVirtualAccountStatus"},
+	"/snap/v1.0/transfer-va/inquiry-va":    {name: "InquiryVirtualAccount", readOnly: true},
+	"/snap/v1.0/transfer-va/delete-va":     {name: "DeleteVirtualAccount", idempotent: true},
+	"/snap/v1.0/transfer-va/report":        {name: "GetVirtualAccountReport", readOnly: true},
+	"/snap/v1.0/transfer-va/status":        {name: "InquiryVirtualAccountStatus", readOnly: true},
+	"/snap/v1.0/balance-inquiry":           {name: "BalanceInquiry", readOnly: true},
+}
+
+// operationName returns the operation name for path, falling back to the path itself
+func operationName(path string) string {
+	if op, ok := operations[path]; ok {
+		return op.name
+	}
+	return path
+}
+
+// retryAllowed reports whether a request to path may be retried automatically.
+// WithIdempotent takes precedence; unknown paths, such as those sent with Do, are retried.
+func retryAllowed(ctx context.Context, path string) bool {
+	if idempotent, ok := idempotentFromContext(ctx); ok {
+		return idempotent
+	}
+	op, ok := operations[path]
+	if !ok || op.readOnly {
+		return true
+	}
+	_, pinned := externalIDFromContext(ctx)
+	return op.idempotent && pinned
+}
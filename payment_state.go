@@ -0,0 +1,95 @@
+package gobriva
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultPollInterval is used when PollPaymentStatus is given no interval
+const defaultPollInterval = 5 * time.Second
+
+// ErrVAExpired is returned by PollPaymentStatus when the VA expires before it is paid
+var ErrVAExpired = errors.New("virtual account expired before payment")
+
+// PaymentState is the business state of a virtual account derived from a status inquiry
+type PaymentState string
+
+const (
+	StateNotFound PaymentState = "NotFound" // BRI does not know the VA
+	StateUnpaid   PaymentState = "Unpaid"   // The VA exists and awaits payment
+	StatePaid     PaymentState = "Paid"     // The VA has been paid
+	StateExpired  PaymentState = "Expired"  // The VA was not paid before its expiredDate
+)
+
+// PaymentState inquires the VA status and classifies it. A missing VA is reported as
+// StateNotFound rather than an error; other failures are returned as errors.
+func (c *Client) PaymentState(ctx context.Context, req *InquiryVirtualAccountStatusRequest) (PaymentState, error) {
+	resp, err := c.InquiryVirtualAccountStatus(ctx, req)
+	if err != nil {
+		var briErr *StructuredBRIAPIResponse
+		if errors.As(err, &briErr) && briErr.HTTPStatusCode == http.StatusNotFound {
+			return StateNotFound, nil
+		}
+		return "", err
+	}
+	return c.responsePaymentState(resp)
+}
+
+// responsePaymentState classifies a successful status inquiry response
+func (c *Client) responsePaymentState(resp *InquiryVirtualAccountStatusResponse) (PaymentState, error) {
+	data := resp.VirtualAccountData
+	if data == nil {
+		return "", fmt.Errorf("%w (responseCode: %s)", ErrMissingVAData, resp.ResponseCode)
+	}
+
+	if data.PaidStatusValue() == PaidStatusYes {
+		return StatePaid, nil
+	}
+	if data.ExpiredDate != "" {
+		expiredAt, err := ParseBRIDateTime(data.ExpiredDate)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse expired date: %w", err)
+		}
+		if !c.now().Before(expiredAt) {
+			return StateExpired, nil
+		}
+	}
+	return StateUnpaid, nil
+}
+
+// PollPaymentStatus inquires the VA status every interval until it is paid, returning the
+// paid response. It stops with ErrVAExpired once the VA expires unpaid, and with the
+// context's error when ctx is cancelled or its deadline passes first.
+func (c *Client) PollPaymentStatus(ctx context.Context, req *InquiryVirtualAccountStatusRequest, interval time.Duration) (*InquiryVirtualAccountStatusResponse, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		resp, err := c.InquiryVirtualAccountStatus(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("payment not received before polling stopped: %w", ctx.Err())
+			}
+			return nil, err
+		}
+
+		state, err := c.responsePaymentState(resp)
+		if err != nil {
+			return resp, err
+		}
+		switch state {
+		case StatePaid:
+			return resp, nil
+		case StateExpired:
+			return resp, ErrVAExpired
+		}
+
+		if err := c.sleep(ctx, interval); err != nil {
+			return nil, fmt.Errorf("payment not received before polling stopped: %w", err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package gobriva
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// defaultLogRedactFields are masked in debug logs when Config.LogRedactFields is nil
+var defaultLogRedactFields = []string{"virtualAccountName", "accessToken"}
+
+// alwaysRedactedHeaders carry credentials and are masked in debug logs regardless of configuration
+var alwaysRedactedHeaders = []string{"Authorization", "X-SIGNATURE"}
+
+// newLogRedactions builds the case-insensitive set of names masked in debug logs
+func newLogRedactions(fields []string) map[string]bool {
+	if fields == nil {
+		fields = defaultLogRedactFields
+	}
+	redactions := make(map[string]bool, len(fields))
+	for _, name := range fields {
+		redactions[strings.ToLower(name)] = true
+	}
+	return redactions
+}
+
+// isRedacted reports whether a header or JSON field is masked in debug logs
+func (c *Client) isRedacted(name string) bool {
+	for _, header := range alwaysRedactedHeaders {
+		if strings.EqualFold(name, header) {
+			return true
+		}
+	}
+	return c.logRedactions[strings.ToLower(name)]
+}
+
+// headersForLog copies headers for debug logging, masking redacted ones
+func (c *Client) headersForLog(header http.Header) map[string][]string {
+	headers := make(map[string][]string, len(header))
+	for name, values := range header {
+		if c.isRedacted(name) {
+			headers[name] = []string{redactedToken}
+			continue
+		}
+		headers[name] = append([]string(nil), values...)
+	}
+	return headers
+}
+
+// bodyForLog masks redacted JSON fields at any depth and truncates the body for debug logging.
+// Bodies that are not JSON are logged as received.
+func (c *Client) bodyForLog(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err == nil && c.redactValue(value) {
+		if masked, err := json.Marshal(value); err == nil {
+			body = masked
+		}
+	}
+
+	if len(body) > maxLogBodySize {
+		return string(body[:maxLogBodySize]) + "... (truncated)"
+	}
+	return string(body)
+}
+
+// redactValue masks redacted fields in a decoded JSON value and reports whether any were found
+func (c *Client) redactValue(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if c.isRedacted(key) {
+				v[key] = redactedToken
+				redacted = true
+				continue
+			}
+			redacted = c.redactValue(field) || redacted
+		}
+	case []any:
+		for _, item := range v {
+			redacted = c.redactValue(item) || redacted
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,57 @@
+package gobriva
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// redactedToken replaces the access token in support material
+const redactedToken = "[REDACTED]"
+
+// ReproBundle captures everything BRI support needs to check a request signature
+type ReproBundle struct {
+	Method       string
+	Path         string
+	Timestamp    string
+	StringToSign string            // Canonical string-to-sign, with the access token redacted
+	Headers      map[string]string // Headers as they would be sent, with the access token redacted
+	Body         string            // Serialized body, identical to what is hashed and transmitted
+	Signature    string
+}
+
+// ReproBundle signs a request the way it would be sent with ctx, without sending it, for
+// attaching to BRI support tickets. Extra and context-scoped headers such as WithExternalID
+// are included. It uses the currently cached token and does not authenticate.
+func (c *Client) ReproBundle(ctx context.Context, method, path string, body any) (ReproBundle, error) {
+	bodyBytes, err := c.marshalBody(body)
+	if err != nil {
+		return ReproBundle{}, err
+	}
+
+	header := make(http.Header)
+	signed, err := c.signRequest(ctx, header, method, path, bodyBytes)
+	if err != nil {
+		return ReproBundle{}, err
+	}
+
+	headers := make(map[string]string, len(header))
+	for k := range header {
+		headers[k] = header.Get(k)
+	}
+	signedToken := ""
+	if signed.accessToken != "" {
+		signedToken = redactedToken
+		headers["Authorization"] = strings.Replace(headers["Authorization"], signed.accessToken, redactedToken, 1)
+	}
+
+	return ReproBundle{
+		Method:       method,
+		Path:         path,
+		Timestamp:    signed.timestamp,
+		StringToSign: stringToSign(method, path, signedToken, string(bodyBytes), signed.timestamp),
+		Headers:      headers,
+		Body:         string(bodyBytes),
+		Signature:    signed.signature,
+	}, nil
+}
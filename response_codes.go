@@ -2,6 +2,8 @@ package gobriva
 
 import (
 	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +26,72 @@ const (
 	CategoryPending             HttpCategory = "Pending"
 )
 
+// Description returns a human-readable explanation of the category, suitable for UIs
+func (c HttpCategory) Description() string {
+	switch c {
+	case CategorySuccess:
+		return "The request was processed successfully."
+	case CategoryBadRequest:
+		return "The request contains invalid or missing data; correct it before retrying."
+	case CategoryUnauthorized:
+		return "Authentication with BRI failed; check credentials, signature and timestamp."
+	case CategoryForbidden:
+		return "The partner is not permitted to perform this operation."
+	case CategoryNotFound:
+		return "The requested virtual account or transaction was not found."
+	case CategoryMethodNotAllowed:
+		return "The HTTP method is not allowed for this endpoint."
+	case CategoryConflict:
+		return "The request conflicts with an existing virtual account or transaction."
+	case CategoryInternalServerError:
+		return "BRI encountered an internal error; retry later or contact support."
+	case CategoryBadGateway:
+		return "A gateway between the client and BRI failed; retry later."
+	case CategoryServiceUnavailable:
+		return "The BRI service is temporarily unavailable; retry later."
+	case CategoryPending:
+		return "The outcome is unknown and requires manual verification."
+	default:
+		return fmt.Sprintf("Unknown category %q.", string(c))
+	}
+}
+
+// severity ranks categories for alert routing; higher is worse
+func (c HttpCategory) severity() int {
+	switch c {
+	case CategorySuccess:
+		return 0
+	case CategoryPending:
+		return 1
+	case CategoryBadRequest:
+		return 2
+	case CategoryUnauthorized, CategoryForbidden, CategoryNotFound, CategoryMethodNotAllowed:
+		return 3
+	case CategoryConflict:
+		return 4
+	case CategoryServiceUnavailable, CategoryBadGateway:
+		return 5
+	default:
+		// Internal server errors and unknown categories rank worst
+		return 6
+	}
+}
+
+// CompareSeverity compares the severity of two response codes by category.
+// It returns -1 if a is less severe than b, 1 if a is more severe, and 0 if they rank equally.
+func CompareSeverity(a, b string) int {
+	severityA := GetBRIVAResponseDefinition(a).Category.severity()
+	severityB := GetBRIVAResponseDefinition(b).Category.severity()
+	switch {
+	case severityA < severityB:
+		return -1
+	case severityA > severityB:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // BRIResponseCode represents a structured BRI API response code
 // Format: HTTPSTATUS(3) + SERVICECODE(2) + CASECODE(2)
 // Example: "2002700" = HTTP 200 + Service 27 + Case 00
@@ -69,6 +137,40 @@ func (rc *BRIResponseCode) GetCaseCode() int {
 	return rc.CaseCode
 }
 
+// ParseBRIResponseCode splits a 7-digit response code into its HTTP status, service and case codes
+func ParseBRIResponseCode(code string) (*BRIResponseCode, error) {
+	if len(code) != 7 || !isDigitString(code) {
+		return nil, fmt.Errorf("invalid response code %q: must be 7 digits", code)
+	}
+
+	// All-digit input of fixed length always parses
+	httpStatus, _ := strconv.Atoi(code[0:3])
+	serviceCode, _ := strconv.Atoi(code[3:5])
+	caseCode, _ := strconv.Atoi(code[5:7])
+
+	return &BRIResponseCode{
+		HTTPStatus:  httpStatus,
+		ServiceCode: serviceCode,
+		CaseCode:    caseCode,
+		FullCode:    code,
+	}, nil
+}
+
+// Service codes carried in digits 4-5 of a response code
+const (
+	ServiceCodeTransactionStatus = 26 // Transaction status inquiry
+	ServiceCodeVirtualAccount    = 27 // BRIVA virtual account operations
+)
+
+// ServiceCodeOf returns the 2-digit service code of a 7-digit response code
+func ServiceCodeOf(code string) (int, error) {
+	rc, err := ParseBRIResponseCode(code)
+	if err != nil {
+		return 0, err
+	}
+	return rc.ServiceCode, nil
+}
+
 // BRIVAResponseDefinition contains detailed information about a BRIVA response code
 type BRIVAResponseDefinition struct {
 	ResponseCode *BRIResponseCode
@@ -472,6 +574,38 @@ func GetBRIVAResponseDefinition(code string) *BRIVAResponseDefinition {
 	return getPendingResponseDefinition(code)
 }
 
+// ListResponseDefinitions returns copies of all known BRIVA response definitions, ordered by code
+func ListResponseDefinitions() []*BRIVAResponseDefinition {
+	return findResponseDefinitions(func(*BRIVAResponseDefinition) bool { return true })
+}
+
+// FindResponseDefinitionsByCategory returns copies of the known definitions in a category, ordered by code
+func FindResponseDefinitionsByCategory(cat HttpCategory) []*BRIVAResponseDefinition {
+	return findResponseDefinitions(func(d *BRIVAResponseDefinition) bool { return d.Category == cat })
+}
+
+// findResponseDefinitions copies the definitions matching keep so callers cannot modify the registry
+func findResponseDefinitions(keep func(*BRIVAResponseDefinition) bool) []*BRIVAResponseDefinition {
+	codes := make([]string, 0, len(brivaResponseDefinitions))
+	for code, definition := range brivaResponseDefinitions {
+		if keep(definition) {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	definitions := make([]*BRIVAResponseDefinition, len(codes))
+	for i, code := range codes {
+		definition := *brivaResponseDefinitions[code]
+		if definition.ResponseCode != nil {
+			responseCode := *definition.ResponseCode
+			definition.ResponseCode = &responseCode
+		}
+		definitions[i] = &definition
+	}
+	return definitions
+}
+
 // getPendingResponseDefinition creates a default definition for unknown response codes
 func getPendingResponseDefinition(code string) *BRIVAResponseDefinition {
 	// Try to parse the response code to determine HTTP status
@@ -510,10 +644,28 @@ func getPendingResponseDefinition(code string) *BRIVAResponseDefinition {
 
 // StructuredBRIAPIResponse provides response information from the API
 type StructuredBRIAPIResponse struct {
-	ResponseCode    string    // The actual response code from API
-	ResponseMessage string    // The actual response message from API
-	HTTPStatusCode  int       // HTTP status code
-	Timestamp       time.Time // When the error occurred
+	ResponseCode    string         // The actual response code from API
+	ResponseMessage string         // The actual response message from API
+	HTTPStatusCode  int            // HTTP status code
+	Timestamp       time.Time      // When the error occurred
+	RetryAfter      time.Duration  // Wait suggested by the Retry-After header (0 if absent)
+	RateLimit       *RateLimitInfo // Parsed X-RateLimit-* headers (nil if absent)
+}
+
+// RateLimitInfo holds the rate-limit metadata BRI's gateway reports in X-RateLimit-* headers.
+// Fields whose header is absent or malformed are left at zero.
+type RateLimitInfo struct {
+	Limit     int       // X-RateLimit-Limit: requests allowed in the window
+	Remaining int       // X-RateLimit-Remaining: requests left in the window
+	Reset     time.Time // X-RateLimit-Reset: when the window resets
+}
+
+// rateLimitResponseCode is returned by BRI's gateway when the rate limit is exceeded
+const rateLimitResponseCode = "5032702"
+
+// IsRateLimited checks if this response reports an exceeded gateway rate limit
+func (e *StructuredBRIAPIResponse) IsRateLimited() bool {
+	return e.ResponseCode == rateLimitResponseCode || e.HTTPStatusCode == http.StatusTooManyRequests
 }
 
 // Error implements the error interface
@@ -551,6 +703,19 @@ func (e *StructuredBRIAPIResponse) extractFieldFromMessage() string {
 	return ""
 }
 
+// LogFields returns the error as flat key/value pairs for structured logging,
+// e.g. slog.Any("error", err.LogFields()). The category is that of the response code.
+func (e *StructuredBRIAPIResponse) LogFields() map[string]any {
+	return map[string]any{
+		"code":       e.ResponseCode,
+		"message":    e.ResponseMessage,
+		"category":   string(e.responseCategory()),
+		"field":      e.extractFieldFromMessage(),
+		"httpStatus": e.HTTPStatusCode,
+		"timestamp":  e.Timestamp,
+	}
+}
+
 // GetTimestamp returns when the error occurred
 func (e *StructuredBRIAPIResponse) GetTimestamp() time.Time {
 	return e.Timestamp
@@ -570,6 +735,15 @@ func (e *StructuredBRIAPIResponse) GetCategory() HttpCategory {
 	}
 }
 
+// responseCategory returns the registry category of the response code, falling back to
+// GetCategory for unknown or empty codes
+func (e *StructuredBRIAPIResponse) responseCategory() HttpCategory {
+	if definition, ok := brivaResponseDefinitions[e.ResponseCode]; ok {
+		return definition.Category
+	}
+	return e.GetCategory()
+}
+
 // IsSuccess checks if this is a success response
 func (e *StructuredBRIAPIResponse) IsSuccess() bool {
 	return e.HTTPStatusCode >= 200 && e.HTTPStatusCode < 300
@@ -585,6 +759,36 @@ func (e *StructuredBRIAPIResponse) IsPending() bool {
 	return e.GetCategory() == CategoryPending
 }
 
+// IsRetryable checks if the request may succeed when retried: a bad gateway, service
+// unavailable or gateway timeout response, or one with a pending status
+func (e *StructuredBRIAPIResponse) IsRetryable() bool {
+	switch e.HTTPStatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return e.IsPending()
+}
+
+// IsAuthError checks if this is an unauthorized (401) or forbidden (403) response
+func (e *StructuredBRIAPIResponse) IsAuthError() bool {
+	return e.HTTPStatusCode == http.StatusUnauthorized || e.HTTPStatusCode == http.StatusForbidden
+}
+
+// IsDuplicate checks if BRI rejected the request because its trxId was already used (4092703)
+func (e *StructuredBRIAPIResponse) IsDuplicate() bool {
+	return e.ResponseCode == trxIDConflictResponseCode
+}
+
+// IsAlreadyExists checks if BRI rejected a creation because the virtual account, its number
+// or its customer number already exists (4092701, 4092702 or 4092704)
+func (e *StructuredBRIAPIResponse) IsAlreadyExists() bool {
+	switch e.ResponseCode {
+	case "4092701", "4092702", "4092704":
+		return true
+	}
+	return false
+}
+
 // NewStructuredBRIAPIResponse creates a new structured BRI API response
 func NewStructuredBRIAPIResponse(responseCode, responseMessage string) *StructuredBRIAPIResponse {
 	// Extract HTTP status code from response code (first 3 digits)
@@ -0,0 +1,176 @@
+package gobriva
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// flexString decodes a JSON string or number into a string.
+// Some gateways serialize responseCode (e.g. 2002700) or expiresIn (e.g. 899) as numbers.
+type flexString string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *flexString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*f = flexString(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("value must be a string or number: %w", err)
+	}
+	*f = flexString(n.String())
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *CreateVirtualAccountResponse) UnmarshalJSON(data []byte) error {
+	type alias CreateVirtualAccountResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *UpdateVirtualAccountResponse) UnmarshalJSON(data []byte) error {
+	type alias UpdateVirtualAccountResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *UpdateVirtualAccountStatusResponse) UnmarshalJSON(data []byte) error {
+	type alias UpdateVirtualAccountStatusResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *InquiryVirtualAccountStatusResponse) UnmarshalJSON(data []byte) error {
+	type alias InquiryVirtualAccountStatusResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *InquiryVirtualAccountResponse) UnmarshalJSON(data []byte) error {
+	type alias InquiryVirtualAccountResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *DeleteVirtualAccountResponse) UnmarshalJSON(data []byte) error {
+	type alias DeleteVirtualAccountResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *VirtualAccountReportResponse) UnmarshalJSON(data []byte) error {
+	type alias VirtualAccountReportResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *BalanceInquiryResponse) UnmarshalJSON(data []byte) error {
+	type alias BalanceInquiryResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts responseCode as either a string or a number
+func (r *ErrorResponse) UnmarshalJSON(data []byte) error {
+	type alias ErrorResponse
+	aux := struct {
+		*alias
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
+
+// UnmarshalJSON accepts expiresIn and responseCode as either a string or a number
+func (r *AuthResponse) UnmarshalJSON(data []byte) error {
+	type alias AuthResponse
+	aux := struct {
+		*alias
+		ExpiresIn    flexString `json:"expiresIn"`
+		ResponseCode flexString `json:"responseCode"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.ExpiresIn = string(aux.ExpiresIn)
+	r.ResponseCode = string(aux.ResponseCode)
+	return nil
+}
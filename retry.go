@@ -0,0 +1,198 @@
+package gobriva
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultRetryBaseDelay is used when RetryPolicy.BaseDelay is not set
+const defaultRetryBaseDelay = 200 * time.Millisecond
+
+// defaultRetryMaxDelay is used when RetryPolicy.MaxDelay is not set
+const defaultRetryMaxDelay = 30 * time.Second
+
+// clockPollInterval is how often sleep checks an injected Config.Clock
+const clockPollInterval = 10 * time.Millisecond
+
+// RetryPolicy configures retries of transient failures. The zero value disables retries.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt
+	BaseDelay  time.Duration // Delay before the first retry, doubled per attempt with jitter (default: 200ms)
+	MaxDelay   time.Duration // Upper bound of the doubled delay (default: 30s)
+
+	// Retryable reports whether a response should be retried (default: DefaultRetryable).
+	// The category is derived from the BRI response code, falling back to the HTTP status.
+	// Network errors are always retried.
+	Retryable func(statusCode int, category HttpCategory) bool
+}
+
+// DefaultRetryable retries bad gateway, service unavailable and timeout responses
+func DefaultRetryable(statusCode int, category HttpCategory) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return category == CategoryBadGateway || category == CategoryServiceUnavailable
+}
+
+// shouldRetry reports whether an attempt failed transiently per the policy
+func (p RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return false
+	}
+
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryable
+	}
+	return retryable(resp.StatusCode, responseCategory(resp))
+}
+
+// backoff returns the jittered delay before the given retry attempt (0-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	if delay <= 0 {
+		delay = defaultRetryBaseDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	// Double step by step so large attempts cannot overflow the duration
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay <<= 1
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	// Jitter within the upper half of the delay spreads out concurrent retries
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// responseCategory derives the category of an error response.
+// The response body is restored so the caller can still read it.
+func responseCategory(resp *http.Response) HttpCategory {
+	_, category := peekResponseCode(resp)
+	return category
+}
+
+// responsePeekLimit bounds how much of a response body peekResponseCode buffers. SNAP
+// responses put responseCode before bulky fields such as virtualAccountData.
+const responsePeekLimit = 4 << 10
+
+// peekResponseCode returns the BRI response code, if any, and category of a response.
+// Only the first responsePeekLimit bytes are read, and the body is restored so the caller
+// can still read all of it.
+func peekResponseCode(resp *http.Response) (string, HttpCategory) {
+	prefix, _ := io.ReadAll(io.LimitReader(resp.Body, responsePeekLimit))
+	resp.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(prefix), resp.Body), Closer: resp.Body}
+
+	if code := responseCodeFromPrefix(prefix); code != "" {
+		return code, GetBRIVAResponseDefinition(code).Category
+	}
+	return "", (&StructuredBRIAPIResponse{HTTPStatusCode: resp.StatusCode}).GetCategory()
+}
+
+// peekedBody replays a peeked prefix before the rest of a body, closing the original body
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// responseCodeFromPrefix scans the top-level fields of a possibly truncated JSON object
+// for responseCode, returning "" if it is not found
+func responseCodeFromPrefix(prefix []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(prefix))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return ""
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if key == "responseCode" {
+			var code flexString
+			if dec.Decode(&code) != nil {
+				return ""
+			}
+			return string(code)
+		}
+		var skip json.RawMessage
+		if dec.Decode(&skip) != nil {
+			return ""
+		}
+	}
+	return ""
+}
+
+// sendWithRetry sends a request, retrying transient failures per the client's RetryPolicy.
+// Operations that are unsafe to replay are sent once, see WithIdempotent.
+// Every attempt goes through sendRequest, so it gets a fresh timestamp and signature, and a
+// fresh external ID unless one is pinned with WithExternalID.
+func (c *Client) sendWithRetry(ctx context.Context, method, path string, bodyBytes []byte) (*http.Response, error) {
+	maxRetries := c.retryPolicy.MaxRetries
+	if !retryAllowed(ctx, path) {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendRequest(ctx, method, path, bodyBytes)
+		if attempt >= maxRetries || ctx.Err() != nil || !c.retryPolicy.shouldRetry(resp, err) || !c.allowRetry() {
+			return resp, err
+		}
+		if resp != nil {
+			respBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			err = parseErrorResponse(resp, respBody, c.now())
+		}
+		if history, ok := retryHistoryFromContext(ctx); ok {
+			history.add(err)
+		}
+
+		if err := c.sleep(ctx, c.retryPolicy.backoff(attempt)); err != nil {
+			return nil, fmt.Errorf("waiting to retry request: %w", err)
+		}
+	}
+}
+
+// RetryHistory collects the transient errors that were retried during calls made with
+// the context returned by WithRetryHistory. It is safe for concurrent use.
+type RetryHistory struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// add records a retried error
+func (h *RetryHistory) add(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.errs = append(h.errs, err)
+}
+
+// PriorErrors returns the retried errors in the order they occurred; API errors are
+// *StructuredBRIAPIResponse values
+func (h *RetryHistory) PriorErrors() []error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]error(nil), h.errs...)
+}
@@ -0,0 +1,66 @@
+package gobriva
+
+import (
+	"sync"
+	"time"
+)
+
+// retryBudget is a token bucket shared by every retry the client performs
+type retryBudget struct {
+	mu         sync.Mutex
+	capacity   float64
+	refillRate float64 // tokens per second
+	tokens     float64
+	last       time.Time
+	now        func() time.Time
+}
+
+// newRetryBudget creates a full bucket holding capacity retries, refilled using now
+func newRetryBudget(capacity int, refillRate float64, now func() time.Time) *retryBudget {
+	return &retryBudget{
+		capacity:   float64(capacity),
+		refillRate: refillRate,
+		tokens:     float64(capacity),
+		last:       now(),
+		now:        now,
+	}
+}
+
+// refill adds tokens earned since the last call; b.mu must be held
+func (b *retryBudget) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.refillRate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+}
+
+// take consumes one retry, reporting false when the budget is exhausted
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// remaining returns the number of whole retries currently available
+func (b *retryBudget) remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	return int(b.tokens)
+}
+
+// allowRetry reports whether the client may retry, consuming budget when one is configured
+func (c *Client) allowRetry() bool {
+	return c.retryBudget == nil || c.retryBudget.take()
+}
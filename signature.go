@@ -0,0 +1,165 @@
+package gobriva
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+// SignHMAC returns the base64-encoded HMAC-SHA512 of payload, as used for API request signatures
+func SignHMAC(secret, payload string) string {
+	h := hmac.New(sha512.New, []byte(secret))
+	h.Write([]byte(payload))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// VerifyHMAC reports whether signature is the valid HMAC-SHA512 signature of payload
+func VerifyHMAC(secret, payload, signature string) bool {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	h := hmac.New(sha512.New, []byte(secret))
+	h.Write([]byte(payload))
+	return hmac.Equal(h.Sum(nil), expected)
+}
+
+// SignRSA returns the base64-encoded RSA-SHA256 (PKCS#1 v1.5) signature of payload, as used for token requests
+func SignRSA(privateKeyPEM, payload string) (string, error) {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return "", err
+	}
+	return signRSA(privateKey, payload, crypto.SHA256, SignatureAlgorithmPKCS1v15)
+}
+
+// SignatureAlgorithm selects the RSA signature scheme used for token requests
+type SignatureAlgorithm string
+
+const (
+	SignatureAlgorithmPKCS1v15 SignatureAlgorithm = "PKCS1v15" // RSASSA-PKCS1-v1_5, BRI's standard (default)
+	SignatureAlgorithmPSS      SignatureAlgorithm = "PSS"      // RSASSA-PSS with a salt as long as the hash
+)
+
+// signRSA returns the base64-encoded signature of payload hashed with hash, using
+// PKCS#1 v1.5 unless algorithm selects PSS
+func signRSA(privateKey *rsa.PrivateKey, payload string, hash crypto.Hash, algorithm SignatureAlgorithm) (string, error) {
+	if !hash.Available() {
+		return "", fmt.Errorf("unsupported signature hash %v", hash)
+	}
+
+	h := hash.New()
+	h.Write([]byte(payload))
+	digest := h.Sum(nil)
+
+	var signature []byte
+	var err error
+	switch algorithm {
+	case "", SignatureAlgorithmPKCS1v15:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, privateKey, hash, digest)
+	case SignatureAlgorithmPSS:
+		signature, err = rsa.SignPSS(rand.Reader, privateKey, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	default:
+		return "", fmt.Errorf("unsupported signature algorithm %q", algorithm)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyRSA verifies a base64-encoded RSA-SHA256 (PKCS#1 v1.5) signature of payload.
+// publicKeyPEM may be a PKIX ("PUBLIC KEY") or PKCS#1 ("RSA PUBLIC KEY") block.
+func VerifyRSA(publicKeyPEM, payload, signature string) error {
+	publicKey, err := parseRSAPublicKey(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	hashed := sha256.Sum256([]byte(payload))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], sig); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return nil
+}
+
+// parseRSAPrivateKey parses a PEM-encoded PKCS#1 or PKCS#8 RSA private key
+func parseRSAPrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	return parseEncryptedRSAPrivateKey(privateKeyPEM, "")
+}
+
+// parseEncryptedRSAPrivateKey parses a PEM-encoded RSA private key, decrypting it with
+// passphrase if it is an encrypted PKCS#8 block or a legacy encrypted PEM block
+func parseEncryptedRSAPrivateKey(privateKeyPEM, passphrase string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block containing private key")
+	}
+
+	der := block.Bytes
+	// Legacy encrypted PEM is deprecated as insecure but still shipped by some onboarding packages
+	if block.Type == "ENCRYPTED PRIVATE KEY" || x509.IsEncryptedPEMBlock(block) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("private key is encrypted but no passphrase is configured")
+		}
+		var err error
+		if block.Type == "ENCRYPTED PRIVATE KEY" {
+			der, err = decryptPKCS8(block.Bytes, passphrase)
+		} else {
+			der, err = x509.DecryptPEMBlock(block, []byte(passphrase))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+		}
+	}
+
+	if parsedKey, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return parsedKey, nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not RSA")
+	}
+	return rsaKey, nil
+}
+
+// parseRSAPublicKey parses a PEM-encoded PKIX or PKCS#1 RSA public key
+func parseRSAPublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block containing public key")
+	}
+
+	if parsedKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return parsedKey, nil
+	}
+
+	parsedKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	rsaKey, ok := parsedKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not RSA")
+	}
+	return rsaKey, nil
+}
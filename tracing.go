@@ -0,0 +1,42 @@
+package gobriva
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer starts a span for each API request. It mirrors the parts of OpenTelemetry the
+// client needs, so an OTel tracer and propagator can be adapted without this package
+// depending on OTel.
+type Tracer interface {
+	// Start starts a span and returns a context carrying it
+	Start(ctx context.Context, name string) (context.Context, Span)
+
+	// Inject writes the trace context in ctx into the outgoing request headers
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is a single traced API request
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+// annotateSpan records the outcome of a request on its span
+func annotateSpan(span Span, method string, resp *http.Response, err error) {
+	span.SetAttribute("http.request.method", method)
+	if err != nil {
+		span.RecordError(err)
+	}
+	if resp == nil {
+		return
+	}
+
+	responseCode, category := peekResponseCode(resp)
+	span.SetAttribute("http.response.status_code", resp.StatusCode)
+	span.SetAttribute("bri.category", string(category))
+	if responseCode != "" {
+		span.SetAttribute("bri.response_code", responseCode)
+	}
+}
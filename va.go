@@ -3,13 +3,47 @@ package gobriva
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"time"
 )
 
-// CreateVirtualAccount creates a new virtual account
+// CreateVirtualAccount creates a new virtual account. A reused trxId is reported as a
+// *StructuredBRIAPIResponse for which IsDuplicate is true; an existing VA, for which IsAlreadyExists is.
+// With Config.RegenerateTrxIDOnConflict set and an empty req.TrxID, the SDK generates the
+// trxId and retries with a fresh one if BRI reports it already exists.
 func (c *Client) CreateVirtualAccount(ctx context.Context, req *CreateVirtualAccountRequest) (*CreateVirtualAccountResponse, error) {
+	if !c.regenerateTrxIDOnConflict || req.TrxID != "" {
+		return c.createVirtualAccount(ctx, req)
+	}
+
+	// Work on a copy so the caller's request keeps its empty trxId
+	generated := *req
+	for attempt := 0; ; attempt++ {
+		generated.TrxID = c.generateTrxID()
+		createResp, err := c.createVirtualAccount(ctx, &generated)
+
+		var briErr *StructuredBRIAPIResponse
+		if attempt >= maxTrxIDRegenerations || !errors.As(err, &briErr) || briErr.ResponseCode != trxIDConflictResponseCode {
+			return createResp, err
+		}
+	}
+}
+
+// createVirtualAccount sends a single create virtual account request
+func (c *Client) createVirtualAccount(ctx context.Context, req *CreateVirtualAccountRequest) (*CreateVirtualAccountResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkVirtualAccountNo(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo); err != nil {
+		return nil, err
+	}
+	if err := c.checkCurrency(req.TotalAmount); err != nil {
+		return nil, err
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -22,27 +56,83 @@ func (c *Client) CreateVirtualAccount(ctx context.Context, req *CreateVirtualAcc
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Decode response
+	createResp, apiErr, err := decodeResponseTreating[CreateVirtualAccountResponse](resp, "create virtual account", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read create virtual account response: %w", err)
+		return nil, err
+	}
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+	if err := c.checkVAData(createResp.ResponseCode, createResp.VirtualAccountData); err != nil {
+		return nil, err
 	}
 
-	var createResp CreateVirtualAccountResponse
-	if err := json.Unmarshal(respBody, &createResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal create virtual account response: %w", err)
+	if c.verifyAfterCreate {
+		// The VA was created; return the response alongside any verification error
+		if err := c.verifyCreated(ctx, req); err != nil {
+			return createResp, err
+		}
 	}
 
-	return &createResp, nil
+	return createResp, nil
+}
+
+// generateTrxID generates a transaction ID from the current time and random digits
+func (c *Client) generateTrxID() string {
+	return c.now().In(briLocation).Format("20060102150405") + c.generateExternalID()
+}
+
+// verifyCreated inquires a freshly created VA to confirm it exists
+func (c *Client) verifyCreated(ctx context.Context, req *CreateVirtualAccountRequest) error {
+	inquiryReq := NewInquiryVirtualAccountRequest(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo, req.TrxID)
+	if _, err := c.InquiryVirtualAccount(ctx, inquiryReq); err != nil {
+		var briErr *StructuredBRIAPIResponse
+		if errors.As(err, &briErr) && briErr.HTTPStatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrVANotFoundAfterCreate, briErr.Error())
+		}
+		return fmt.Errorf("failed to verify created virtual account: %w", err)
+	}
+	return nil
+}
+
+// CreateVirtualAccountBlocking creates a virtual account, waiting out maintenance windows.
+// On a maintenance response it sleeps for the Retry-After duration (or a default when absent)
+// and retries until the request succeeds, fails for another reason, or ctx is done.
+func (c *Client) CreateVirtualAccountBlocking(ctx context.Context, req *CreateVirtualAccountRequest) (*CreateVirtualAccountResponse, error) {
+	for {
+		resp, err := c.CreateVirtualAccount(ctx, req)
+
+		var briErr *StructuredBRIAPIResponse
+		if err == nil || !errors.As(err, &briErr) || briErr.ResponseCode != maintenanceResponseCode || !c.allowRetry() {
+			return resp, err
+		}
+
+		wait := briErr.RetryAfter
+		if wait <= 0 {
+			wait = defaultMaintenanceWait
+		}
+
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("waiting for maintenance window: %w", err)
+		}
+	}
 }
 
 // UpdateVirtualAccount updates an existing virtual account
 func (c *Client) UpdateVirtualAccount(ctx context.Context, req *UpdateVirtualAccountRequest) (*UpdateVirtualAccountResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkVirtualAccountNo(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo); err != nil {
+		return nil, err
+	}
+	if err := c.checkCurrency(req.TotalAmount); err != nil {
+		return nil, err
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -55,27 +145,37 @@ func (c *Client) UpdateVirtualAccount(ctx context.Context, req *UpdateVirtualAcc
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Decode response
+	updateResp, apiErr, err := decodeResponseTreating[UpdateVirtualAccountResponse](resp, "update virtual account", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read update virtual account response: %w", err)
+		return nil, err
 	}
-
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	var updateResp UpdateVirtualAccountResponse
-	if err := json.Unmarshal(respBody, &updateResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal update virtual account response: %w", err)
+	if err := c.checkVAData(updateResp.ResponseCode, updateResp.VirtualAccountData); err != nil {
+		return nil, err
 	}
 
-	return &updateResp, nil
+	return updateResp, nil
 }
 
 // UpdateVirtualAccountStatus updates the status of a virtual account
 func (c *Client) UpdateVirtualAccountStatus(ctx context.Context, req *UpdateVirtualAccountStatusRequest) (*UpdateVirtualAccountStatusResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkVirtualAccountNo(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo); err != nil {
+		return nil, err
+	}
+	if req.TotalAmount != nil {
+		if err := c.checkCurrency(*req.TotalAmount); err != nil {
+			return nil, err
+		}
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -88,27 +188,32 @@ func (c *Client) UpdateVirtualAccountStatus(ctx context.Context, req *UpdateVirt
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Decode response
+	statusResp, apiErr, err := decodeResponseTreating[UpdateVirtualAccountStatusResponse](resp, "update virtual account status", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read update virtual account status response: %w", err)
+		return nil, err
 	}
-
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	var statusResp UpdateVirtualAccountStatusResponse
-	if err := json.Unmarshal(respBody, &statusResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal update virtual account status response: %w", err)
+	if err := c.checkVAData(statusResp.ResponseCode, statusResp.VirtualAccountData); err != nil {
+		return nil, err
 	}
 
-	return &statusResp, nil
+	return statusResp, nil
 }
 
 // InquiryVirtualAccount gets information about a virtual account
 func (c *Client) InquiryVirtualAccount(ctx context.Context, req *InquiryVirtualAccountRequest) (*InquiryVirtualAccountResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkVirtualAccountNo(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo); err != nil {
+		return nil, err
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -121,27 +226,32 @@ func (c *Client) InquiryVirtualAccount(ctx context.Context, req *InquiryVirtualA
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Decode response
+	inquiryResp, apiErr, err := decodeResponseTreating[InquiryVirtualAccountResponse](resp, "inquiry virtual account", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read inquiry virtual account response: %w", err)
+		return nil, err
 	}
-
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	var inquiryResp InquiryVirtualAccountResponse
-	if err := json.Unmarshal(respBody, &inquiryResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal inquiry virtual account response: %w", err)
+	if err := c.checkVAData(inquiryResp.ResponseCode, inquiryResp.VirtualAccountData); err != nil {
+		return nil, err
 	}
 
-	return &inquiryResp, nil
+	return inquiryResp, nil
 }
 
 // DeleteVirtualAccount deletes a virtual account
 func (c *Client) DeleteVirtualAccount(ctx context.Context, req *DeleteVirtualAccountRequest) (*DeleteVirtualAccountResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkVirtualAccountNo(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo); err != nil {
+		return nil, err
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -154,27 +264,29 @@ func (c *Client) DeleteVirtualAccount(ctx context.Context, req *DeleteVirtualAcc
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Decode response
+	deleteResp, apiErr, err := decodeResponseTreating[DeleteVirtualAccountResponse](resp, "delete virtual account", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read delete virtual account response: %w", err)
+		return nil, err
 	}
-
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	var deleteResp DeleteVirtualAccountResponse
-	if err := json.Unmarshal(respBody, &deleteResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal delete virtual account response: %w", err)
+	if err := c.checkVAData(deleteResp.ResponseCode, deleteResp.VirtualAccountData); err != nil {
+		return nil, err
 	}
 
-	return &deleteResp, nil
+	return deleteResp, nil
 }
 
 // GetVirtualAccountReport gets a report of virtual account transactions
 func (c *Client) GetVirtualAccountReport(ctx context.Context, req *VirtualAccountReportRequest) (*VirtualAccountReportResponse, error) {
+	// Validate before hitting the network
+	if err := req.ValidateSpan(c.reportSpan()); err != nil {
+		return nil, err
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -187,27 +299,152 @@ func (c *Client) GetVirtualAccountReport(ctx context.Context, req *VirtualAccoun
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	if c.lenientReportDecode {
+		respBody, apiErr, err := readResponse(resp, "virtual account report", c.now())
+		if err != nil {
+			return nil, err
+		}
+		if apiErr != nil && !c.treatAsSuccess[apiErr.ResponseCode] {
+			return nil, apiErr
+		}
+		reportResp, err := decodeReportLenient(resp.StatusCode, respBody)
+		if err != nil {
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			return nil, err
+		}
+		if apiErr := reportError(reportResp, c.treatAsSuccess); apiErr != nil {
+			return nil, apiErr
+		}
+		return reportResp, nil
+	}
+
+	// Decode response
+	reportResp, apiErr, err := decodeResponseTreating[VirtualAccountReportResponse](resp, "virtual account report", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read virtual account report response: %w", err)
+		return nil, err
+	}
+	if apiErr != nil {
+		return nil, apiErr
 	}
+	if apiErr := reportError(reportResp, c.treatAsSuccess); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return reportResp, nil
+}
 
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+// IterateVirtualAccountReport walks the report range in consecutive windows of pageSize and calls
+// fn for each transaction, so only one window is held in memory. Windows are inclusive to the
+// second in WIB. It stops at the first API error, fn error or context cancellation and returns it.
+func (c *Client) IterateVirtualAccountReport(ctx context.Context, req *VirtualAccountReportRequest, pageSize time.Duration, fn func(VirtualAccountTransaction) error) error {
+	if err := req.ValidateSpan(c.reportSpan()); err != nil {
+		return err
+	}
+	if pageSize < time.Second {
+		return fmt.Errorf("report page size must be at least one second, got %s", pageSize)
+	}
+
+	endDate := req.EndDate
+	if endDate == "" {
+		endDate = req.StartDate
+	}
+	start, _ := parseReportDateTime(req.StartDate, req.StartTime)
+	end, _ := parseReportDateTime(endDate, req.EndTime)
+	start, end = start.In(briLocation), end.In(briLocation)
+
+	for windowStart := start; !windowStart.After(end); {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		windowEnd := windowStart.Add(pageSize - time.Second)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+		window := &VirtualAccountReportRequest{
+			PartnerServiceID: req.PartnerServiceID,
+			StartDate:        windowStart.Format("2006-01-02"),
+			StartTime:        windowStart.Format("15:04:05"),
+			EndTime:          windowEnd.Format("15:04:05"),
+		}
+		if date := windowEnd.Format("2006-01-02"); date != window.StartDate {
+			window.EndDate = date
+		}
+
+		report, err := c.GetVirtualAccountReport(ctx, window)
+		if err != nil {
+			return fmt.Errorf("report window %s %s to %s: %w", window.StartDate, window.StartTime, window.EndTime, err)
+		}
+		for _, trx := range report.VirtualAccountData {
+			if err := fn(trx); err != nil {
+				return err
+			}
+		}
+
+		windowStart = windowEnd.Add(time.Second)
+	}
+	return nil
+}
+
+// reportError returns an API error when a report response carries a non-success code.
+// BRI may send such codes with HTTP 200 and an empty virtualAccountData array, which
+// must not be mistaken for a successful report with zero transactions. Codes in
+// treatAsSuccess are not errors.
+func reportError(reportResp *VirtualAccountReportResponse, treatAsSuccess map[string]bool) *StructuredBRIAPIResponse {
+	apiErr := NewStructuredBRIAPIResponse(reportResp.ResponseCode, reportResp.ResponseMessage)
+	if apiErr.IsSuccess() || treatAsSuccess[reportResp.ResponseCode] {
+		return nil
+	}
+	return apiErr
+}
+
+// reportSpan returns the configured maximum report window
+func (c *Client) reportSpan() time.Duration {
+	if c.maxReportSpan > 0 {
+		return c.maxReportSpan
+	}
+	return defaultMaxReportSpan
+}
+
+// decodeReportLenient decodes a report response, skipping malformed transactions
+func decodeReportLenient(statusCode int, respBody []byte) (*VirtualAccountReportResponse, error) {
+	var raw struct {
+		ResponseCode       flexString        `json:"responseCode"`
+		ResponseMessage    string            `json:"responseMessage"`
+		VirtualAccountData []json.RawMessage `json:"virtualAccountData,omitempty"`
+	}
+	if err := json.Unmarshal(respBody, &raw); err != nil {
+		return nil, newDecodeError("virtual account report", statusCode, respBody, err)
 	}
 
-	var reportResp VirtualAccountReportResponse
-	if err := json.Unmarshal(respBody, &reportResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal virtual account report response: %w", err)
+	reportResp := &VirtualAccountReportResponse{
+		ResponseCode:    string(raw.ResponseCode),
+		ResponseMessage: raw.ResponseMessage,
+	}
+	for i, item := range raw.VirtualAccountData {
+		var trx VirtualAccountTransaction
+		if err := json.Unmarshal(item, &trx); err != nil {
+			reportResp.DecodeErrors = append(reportResp.DecodeErrors, fmt.Errorf("failed to unmarshal transaction at index %d: %w: %w", i, ErrDecode, err))
+			continue
+		}
+		reportResp.VirtualAccountData = append(reportResp.VirtualAccountData, trx)
 	}
 
-	return &reportResp, nil
+	return reportResp, nil
 }
 
 // InquiryVirtualAccountStatus inquires the status of a virtual account
 func (c *Client) InquiryVirtualAccountStatus(ctx context.Context, req *InquiryVirtualAccountStatusRequest) (*InquiryVirtualAccountStatusResponse, error) {
+	// Validate before hitting the network
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkVirtualAccountNo(req.PartnerServiceID, req.CustomerNo, req.VirtualAccountNo); err != nil {
+		return nil, err
+	}
+
 	// Ensure authentication
 	if err := c.auth.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -220,21 +457,18 @@ func (c *Client) InquiryVirtualAccountStatus(ctx context.Context, req *InquiryVi
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
+	// Decode response
+	inquiryResp, apiErr, err := decodeResponseTreating[InquiryVirtualAccountStatusResponse](resp, "inquiry virtual account status", c.treatAsSuccess, c.now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read inquiry virtual account status response: %w", err)
+		return nil, err
 	}
-
-	// Parse response
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(respBody, resp.StatusCode)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	var inquiryResp InquiryVirtualAccountStatusResponse
-	if err := json.Unmarshal(respBody, &inquiryResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal inquiry virtual account status response: %w", err)
+	if err := c.checkVAData(inquiryResp.ResponseCode, inquiryResp.VirtualAccountData); err != nil {
+		return nil, err
 	}
 
-	return &inquiryResp, nil
+	return inquiryResp, nil
 }
@@ -0,0 +1,345 @@
+package gobriva
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Local validation mirrors the checks BRI performs, so invalid requests fail
+// without a network round trip. Errors use the same response codes BRI returns.
+
+const (
+	// Maximum lengths defined by the SNAP VA specification
+	maxPartnerServiceIDLength = 8
+	maxCustomerNoLength       = 20
+	maxVirtualAccountNoLength = maxPartnerServiceIDLength + maxCustomerNoLength
+
+	// Widest report window BRI accepts by default
+	defaultMaxReportSpan = 31 * 24 * time.Hour
+)
+
+var (
+	amountValuePattern = regexp.MustCompile(`^\d+\.\d{2}$`)
+	currencyPattern    = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// requestField is a named request field checked by validation
+type requestField struct {
+	name  string
+	value string
+}
+
+// mandatoryFieldError builds the error BRI returns for a missing mandatory field
+func mandatoryFieldError(field string) error {
+	return NewStructuredBRIAPIResponse("4002702", "Invalid Mandatory Field "+field)
+}
+
+// fieldFormatError builds the error BRI returns for a malformed field
+func fieldFormatError(field string) error {
+	return NewStructuredBRIAPIResponse("4002701", "Invalid Field Format "+field)
+}
+
+// requireFields returns an error for the first empty field
+func requireFields(fields ...requestField) error {
+	for _, f := range fields {
+		if strings.TrimSpace(f.value) == "" {
+			return mandatoryFieldError(f.name)
+		}
+	}
+	return nil
+}
+
+// validateVirtualAccountNo checks the partnerServiceId, customerNo and virtualAccountNo formats.
+// BRI left-pads these fields with spaces, so padding is ignored when checking digits.
+func validateVirtualAccountNo(partnerServiceID, customerNo, vaNo string) error {
+	if len(partnerServiceID) > maxPartnerServiceIDLength || !isDigitString(strings.TrimLeft(partnerServiceID, " ")) {
+		return fieldFormatError("partnerServiceId")
+	}
+	if len(customerNo) > maxCustomerNoLength {
+		return fieldFormatError("customerNo")
+	}
+	if len(vaNo) > maxVirtualAccountNoLength || !isDigitString(strings.ReplaceAll(vaNo, " ", "")) {
+		return fieldFormatError("virtualAccountNo")
+	}
+	return nil
+}
+
+// FormatPartnerServiceID left-pads a partnerServiceId with spaces to the 8 characters BRI expects
+func FormatPartnerServiceID(base string) (string, error) {
+	digits := strings.TrimLeft(base, " ")
+	if digits == "" || len(base) > maxPartnerServiceIDLength || !isDigitString(digits) {
+		return "", NewStructuredBRIAPIResponse("4002709", "Invalid partner service ID")
+	}
+	return strings.Repeat(" ", maxPartnerServiceIDLength-len(base)) + base, nil
+}
+
+// GenerateVANumber composes a virtualAccountNo from a partnerServiceId (the institution
+// prefix, padded to 8 characters) and a numeric customerNo, as BRI expects
+func GenerateVANumber(partnerServiceID, customerNo string) (string, error) {
+	formatted, err := FormatPartnerServiceID(partnerServiceID)
+	if err != nil {
+		return "", err
+	}
+	if customerNo == "" || len(customerNo) > maxCustomerNoLength || !isDigitString(customerNo) {
+		return "", NewStructuredBRIAPIResponse("4002710", "Invalid customer number")
+	}
+	return formatted + customerNo, nil
+}
+
+// GenerateVANumberWithCheckDigit is GenerateVANumber with a Luhn (mod 10) check digit
+// appended to customerNo, so mistyped VA numbers can be detected before sending
+func GenerateVANumberWithCheckDigit(partnerServiceID, customerNo string) (string, error) {
+	if customerNo == "" || len(customerNo) >= maxCustomerNoLength || !isDigitString(customerNo) {
+		return "", NewStructuredBRIAPIResponse("4002710", "Invalid customer number")
+	}
+	return GenerateVANumber(partnerServiceID, customerNo+string(luhnCheckDigit(strings.TrimSpace(partnerServiceID)+customerNo)))
+}
+
+// luhnCheckDigit returns the Luhn check digit for a string of digits
+func luhnCheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		// Double every second digit from the right, starting with the rightmost
+		if i%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// ValidateVirtualAccountComposition checks that partnerServiceId is 8 characters and that
+// virtualAccountNo is partnerServiceId followed by customerNo, ignoring space padding
+func ValidateVirtualAccountComposition(partnerServiceID, customerNo, vaNo string) error {
+	if len(partnerServiceID) != maxPartnerServiceIDLength {
+		return NewStructuredBRIAPIResponse("4002709", "Invalid partner service ID")
+	}
+	expected := strings.TrimSpace(partnerServiceID) + strings.TrimSpace(customerNo)
+	if strings.ReplaceAll(vaNo, " ", "") != expected {
+		return NewStructuredBRIAPIResponse("4002710", "Invalid customer number")
+	}
+	return nil
+}
+
+// checkVirtualAccountNo enforces the StrictVirtualAccountNo policy
+func (c *Client) checkVirtualAccountNo(partnerServiceID, customerNo, vaNo string) error {
+	if !c.strictVirtualAccountNo {
+		return nil
+	}
+	return ValidateVirtualAccountComposition(partnerServiceID, customerNo, vaNo)
+}
+
+// checkCurrency enforces Config.DefaultCurrency on a request amount
+func (c *Client) checkCurrency(amount Amount) error {
+	if c.defaultCurrency == "" || amount.Currency == c.defaultCurrency {
+		return nil
+	}
+	return fieldFormatError("totalAmount.currency")
+}
+
+// validateAmount checks the amount value has two decimals and the currency is an ISO 4217 code
+func validateAmount(field string, amount Amount) error {
+	if !amountValuePattern.MatchString(amount.Value) {
+		return fieldFormatError(field + ".value")
+	}
+	if !currencyPattern.MatchString(amount.Currency) {
+		return fieldFormatError(field + ".currency")
+	}
+	return nil
+}
+
+// validateExpiredDate checks expiredDate is an ISO 8601 date-time with offset
+func validateExpiredDate(expiredDate string) error {
+	if _, err := time.Parse(time.RFC3339, expiredDate); err != nil {
+		return fieldFormatError("expiredDate")
+	}
+	return nil
+}
+
+// Validate checks the request before it is sent
+func (r *CreateVirtualAccountRequest) Validate() error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"customerNo", r.CustomerNo},
+		requestField{"virtualAccountNo", r.VirtualAccountNo},
+		requestField{"virtualAccountName", r.VirtualAccountName},
+		requestField{"totalAmount.value", r.TotalAmount.Value},
+		requestField{"totalAmount.currency", r.TotalAmount.Currency},
+		requestField{"expiredDate", r.ExpiredDate},
+		requestField{"trxId", r.TrxID},
+	); err != nil {
+		return err
+	}
+	if err := validateVirtualAccountNo(r.PartnerServiceID, r.CustomerNo, r.VirtualAccountNo); err != nil {
+		return err
+	}
+	if err := validateAmount("totalAmount", r.TotalAmount); err != nil {
+		return err
+	}
+	return validateExpiredDate(r.ExpiredDate)
+}
+
+// Validate checks the request before it is sent
+func (r *UpdateVirtualAccountRequest) Validate() error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"customerNo", r.CustomerNo},
+		requestField{"virtualAccountNo", r.VirtualAccountNo},
+		requestField{"virtualAccountName", r.VirtualAccountName},
+		requestField{"totalAmount.value", r.TotalAmount.Value},
+		requestField{"totalAmount.currency", r.TotalAmount.Currency},
+		requestField{"expiredDate", r.ExpiredDate},
+		requestField{"trxId", r.TrxID},
+	); err != nil {
+		return err
+	}
+	if err := validateVirtualAccountNo(r.PartnerServiceID, r.CustomerNo, r.VirtualAccountNo); err != nil {
+		return err
+	}
+	if err := validateAmount("totalAmount", r.TotalAmount); err != nil {
+		return err
+	}
+	return validateExpiredDate(r.ExpiredDate)
+}
+
+// Validate checks the request before it is sent
+func (r *UpdateVirtualAccountStatusRequest) Validate() error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"customerNo", r.CustomerNo},
+		requestField{"virtualAccountNo", r.VirtualAccountNo},
+		requestField{"trxId", r.TrxID},
+		requestField{"paidStatus", r.PaidStatus},
+	); err != nil {
+		return err
+	}
+	if err := validateVirtualAccountNo(r.PartnerServiceID, r.CustomerNo, r.VirtualAccountNo); err != nil {
+		return err
+	}
+	if ParsePaidStatus(r.PaidStatus) == PaidStatusUnknown {
+		return fieldFormatError("paidStatus")
+	}
+	if r.TotalAmount != nil {
+		return validateAmount("totalAmount", *r.TotalAmount)
+	}
+	return nil
+}
+
+// Validate checks the request before it is sent
+func (r *InquiryVirtualAccountRequest) Validate() error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"customerNo", r.CustomerNo},
+		requestField{"virtualAccountNo", r.VirtualAccountNo},
+		requestField{"trxId", r.TrxID},
+	); err != nil {
+		return err
+	}
+	return validateVirtualAccountNo(r.PartnerServiceID, r.CustomerNo, r.VirtualAccountNo)
+}
+
+// Validate checks the request before it is sent
+func (r *InquiryVirtualAccountStatusRequest) Validate() error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"customerNo", r.CustomerNo},
+		requestField{"virtualAccountNo", r.VirtualAccountNo},
+		requestField{"inquiryRequestId", r.InquiryRequestID},
+	); err != nil {
+		return err
+	}
+	return validateVirtualAccountNo(r.PartnerServiceID, r.CustomerNo, r.VirtualAccountNo)
+}
+
+// Validate checks the request before it is sent
+func (r *DeleteVirtualAccountRequest) Validate() error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"customerNo", r.CustomerNo},
+		requestField{"virtualAccountNo", r.VirtualAccountNo},
+		requestField{"trxId", r.TrxID},
+	); err != nil {
+		return err
+	}
+	return validateVirtualAccountNo(r.PartnerServiceID, r.CustomerNo, r.VirtualAccountNo)
+}
+
+// Validate checks the request before it is sent, allowing a report span of up to 31 days
+func (r *VirtualAccountReportRequest) Validate() error {
+	return r.ValidateSpan(defaultMaxReportSpan)
+}
+
+// ValidateSpan checks the request before it is sent, rejecting report windows
+// that end before they start or span more than maxSpan
+func (r *VirtualAccountReportRequest) ValidateSpan(maxSpan time.Duration) error {
+	if err := requireFields(
+		requestField{"partnerServiceId", r.PartnerServiceID},
+		requestField{"startDate", r.StartDate},
+		requestField{"startTime", r.StartTime},
+		requestField{"endTime", r.EndTime},
+	); err != nil {
+		return err
+	}
+	if _, err := time.Parse("2006-01-02", r.StartDate); err != nil {
+		return fieldFormatError("startDate")
+	}
+	if r.EndDate != "" {
+		if _, err := time.Parse("2006-01-02", r.EndDate); err != nil {
+			return fieldFormatError("endDate")
+		}
+	}
+	if !isReportTime(r.StartTime) {
+		return fieldFormatError("startTime")
+	}
+	if !isReportTime(r.EndTime) {
+		return fieldFormatError("endTime")
+	}
+
+	endDate := r.EndDate
+	if endDate == "" {
+		endDate = r.StartDate
+	}
+	start, _ := parseReportDateTime(r.StartDate, r.StartTime)
+	end, _ := parseReportDateTime(endDate, r.EndTime)
+	if end.Before(start) || end.Sub(start) > maxSpan {
+		return NewStructuredBRIAPIResponse("4002716", "Invalid report date range")
+	}
+	return nil
+}
+
+// parseReportDateTime combines a report date and time; times without an offset are in WIB
+func parseReportDateTime(date, clock string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02T15:04:05Z07:00"} {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, date+"T"+clock, briLocation); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// isReportTime accepts report times such as "15:04", "15:04:05" and "15:04:05+07:00"
+func isReportTime(value string) bool {
+	for _, layout := range []string{"15:04", "15:04:05", "15:04:05Z07:00"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the request before it is sent
+func (r *BalanceInquiryRequest) Validate() error {
+	if err := requireFields(requestField{"accountNo", r.AccountNo}); err != nil {
+		return err
+	}
+	if !isDigitString(r.AccountNo) {
+		return fieldFormatError("accountNo")
+	}
+	return nil
+}